@@ -0,0 +1,145 @@
+package rancher
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	rancher "github.com/rancher/go-rancher/client"
+)
+
+// The provider supports the v1 API of Rancher 1.x servers and the v2-beta API
+// of Rancher 1.2 and newer, where stacks are the stack type instead of the
+// environment type, and services reference their stack with stackId.
+const (
+	apiVersionV1     = "v1"
+	apiVersionV2Beta = "v2-beta"
+)
+
+// apiVersionPattern matches the last path segment of URLs that end with an
+// API version, like /v1 or /v2-beta.
+var apiVersionPattern = regexp.MustCompile(`/(v[0-9][0-9a-z.-]*)$`)
+
+// normalizeAPIURL splits an api_url into the URL of the Rancher server and the
+// API version it includes, if any.
+func normalizeAPIURL(apiURL string) (serverURL string, version string) {
+	serverURL = strings.TrimRight(apiURL, "/")
+
+	if match := apiVersionPattern.FindStringSubmatch(serverURL); match != nil {
+		return strings.TrimSuffix(serverURL, match[0]), match[1]
+	}
+
+	return serverURL, ""
+}
+
+// validateAPIVersion checks that the provider supports an API version
+func validateAPIVersion(version string) error {
+	switch version {
+	case apiVersionV1, apiVersionV2Beta:
+		return nil
+	}
+
+	return fmt.Errorf("Unsupported Rancher API version %s in api_url, it must be %s or %s",
+		version, apiVersionV1, apiVersionV2Beta)
+}
+
+// aliasStackType makes the environment operations of the client, which the
+// v1 API uses for stacks, work with the stack type of the v2-beta API.
+func aliasStackType(client *rancher.RancherClient) {
+	base, ok := client.RancherBaseClient.(*rancher.RancherBaseClientImpl)
+	if !ok {
+		return
+	}
+
+	stack, ok := base.Types["stack"]
+	if !ok {
+		return
+	}
+
+	if _, ok := base.Types[rancher.ENVIRONMENT_TYPE]; !ok {
+		base.Types[rancher.ENVIRONMENT_TYPE] = stack
+	}
+}
+
+// schemaHasField returns whether the API schema of a type has a field
+func schemaHasField(client *rancher.RancherClient, schemaType, field string) bool {
+	base, ok := client.RancherBaseClient.(*rancher.RancherBaseClientImpl)
+	if !ok {
+		return false
+	}
+
+	_, ok = base.Types[schemaType].ResourceFields[field]
+	return ok
+}
+
+type apiStack struct {
+	rancher.Environment
+
+	// System is only set by the v2-beta API
+	System bool `json:"system,omitempty"`
+}
+
+type apiStackCollection struct {
+	rancher.Collection
+	Data []apiStack `json:"data,omitempty"`
+}
+
+// listStacks returns the stacks of the environment the client is scoped to
+func listStacks(client *rancher.RancherClient) ([]apiStack, error) {
+	stacks := &apiStackCollection{}
+	if err := client.List(rancher.ENVIRONMENT_TYPE, rancher.NewListOpts(), stacks); err != nil {
+		return nil, err
+	}
+
+	return stacks.Data, nil
+}
+
+// isSystemStack returns whether a stack is an infrastructure stack managed by
+// Rancher itself. The v1 API doesn't return the system flag, so they are also
+// recognized by their external IDs, like system://kubernetes in Rancher 1.1
+// and catalog://library:infra*ipsec:4 in Rancher 1.2.
+func isSystemStack(stack *apiStack) bool {
+	return stack.System ||
+		strings.HasPrefix(stack.ExternalId, "system") ||
+		strings.Contains(stack.ExternalId, ":infra*")
+}
+
+type apiService struct {
+	rancher.Service
+
+	// StackId replaces EnvironmentId in the v2-beta API
+	StackId string `json:"stackId,omitempty"`
+}
+
+type apiServiceCollection struct {
+	rancher.Collection
+	Data []apiService `json:"data,omitempty"`
+}
+
+// listStackServices returns the services of a stack that have not been
+// removed, filtered by opts.
+func listStackServices(client *rancher.RancherClient, stackID string, opts *rancher.ListOpts) ([]rancher.Service, error) {
+	stackField := "environmentId"
+	if schemaHasField(client, rancher.SERVICE_TYPE, "stackId") {
+		stackField = "stackId"
+	}
+	opts.Filters[stackField] = stackID
+
+	services := &apiServiceCollection{}
+	if err := client.List(rancher.SERVICE_TYPE, opts, services); err != nil {
+		return nil, err
+	}
+
+	var found []rancher.Service
+	for _, service := range services.Data {
+		if service.StackId != "" {
+			service.EnvironmentId = service.StackId
+		}
+
+		if service.EnvironmentId == stackID && !isRemovedState(service.State) {
+			found = append(found, service.Service)
+		}
+	}
+
+	return found, nil
+}
@@ -0,0 +1,70 @@
+package rancher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeAPIURL(t *testing.T) {
+	cases := []struct {
+		APIURL    string
+		ServerURL string
+		Version   string
+	}{
+		{"https://rancher.example.com", "https://rancher.example.com", ""},
+		{"https://rancher.example.com/", "https://rancher.example.com", ""},
+		{"https://rancher.example.com/v1", "https://rancher.example.com", "v1"},
+		{"https://rancher.example.com/v1/", "https://rancher.example.com", "v1"},
+		{"https://rancher.example.com/v2-beta", "https://rancher.example.com", "v2-beta"},
+		{"https://example.com/rancher/v2-beta/", "https://example.com/rancher", "v2-beta"},
+		{"https://rancher.example.com/v3", "https://rancher.example.com", "v3"},
+		{"https://example.com/vault", "https://example.com/vault", ""},
+	}
+
+	for _, tc := range cases {
+		serverURL, version := normalizeAPIURL(tc.APIURL)
+		if serverURL != tc.ServerURL || version != tc.Version {
+			t.Fatalf("Bad normalization of %s: %s, %s should be: %s, %s",
+				tc.APIURL, serverURL, version, tc.ServerURL, tc.Version)
+		}
+	}
+}
+
+func TestConfigCreateClient_unversionedURL(t *testing.T) {
+	// The server supports both the v1 and v2-beta APIs
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")[0]
+		w.Header().Set("X-API-Schemas", "http://"+r.Host+"/"+version+"/schemas")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		APIURL:    server.URL + "/",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+
+	if err := config.CreateClient(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if config.APIURL != server.URL+"/v1" {
+		t.Fatalf("Bad API url: %s should be: %s", config.APIURL, server.URL+"/v1")
+	}
+}
+
+func TestConfigCreateClient_unsupportedVersion(t *testing.T) {
+	config := &Config{
+		APIURL:    "https://rancher.example.com/v3",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+
+	err := config.CreateClient()
+	if err == nil || !strings.Contains(err.Error(), "Unsupported Rancher API version v3") {
+		t.Fatalf("Expected an unsupported version error, got: %v", err)
+	}
+}
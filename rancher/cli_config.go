@@ -0,0 +1,58 @@
+package rancher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// defaultCLIConfigPath is where `rancher config` writes the configuration of
+// the Rancher CLI.
+const defaultCLIConfigPath = "~/.rancher/cli.json"
+
+// cliConfig is the configuration file of the Rancher CLI, used as a fallback
+// for the provider settings.
+type cliConfig struct {
+	URL         string `json:"url"`
+	AccessKey   string `json:"accessKey"`
+	SecretKey   string `json:"secretKey"`
+	Environment string `json:"environment"`
+}
+
+// loadCLIConfig reads the Rancher CLI configuration file at path. A missing
+// file at the default path results in an empty configuration.
+func loadCLIConfig(path string) (*cliConfig, error) {
+	if path == "" {
+		return &cliConfig{}, nil
+	}
+
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to expand config path %s: %s", path, err)
+	}
+
+	data, err := ioutil.ReadFile(expanded)
+	if os.IsNotExist(err) && path == defaultCLIConfigPath {
+		return &cliConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read Rancher CLI config %s: %s", path, err)
+	}
+
+	config := &cliConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("Failed to parse Rancher CLI config %s: %s", path, err)
+	}
+
+	// The CLI stores the URL of the API schemas of the version it uses. The
+	// provider uses the same API version for the server whatever the source
+	// of its URL is, since the IDs of stacks differ between versions.
+	config.URL = strings.TrimSuffix(strings.TrimRight(config.URL, "/"), "/schemas")
+	config.URL, _ = normalizeAPIURL(config.URL)
+
+	return config, nil
+}
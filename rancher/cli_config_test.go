@@ -0,0 +1,44 @@
+package rancher
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLoadCLIConfig(t *testing.T) {
+	file, err := ioutil.TempFile("", "cli.json")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(file.Name())
+
+	file.WriteString(`{
+		"accessKey": "access",
+		"secretKey": "secret",
+		"url": "https://rancher.example.com/v2-beta/schemas",
+		"environment": "1a5"
+	}`)
+	file.Close()
+
+	config, err := loadCLIConfig(file.Name())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := cliConfig{
+		URL:         "https://rancher.example.com",
+		AccessKey:   "access",
+		SecretKey:   "secret",
+		Environment: "1a5",
+	}
+	if *config != expected {
+		t.Fatalf("Bad config: %#v should be: %#v", *config, expected)
+	}
+}
+
+func TestLoadCLIConfig_missing(t *testing.T) {
+	if _, err := loadCLIConfig("/nonexistent/cli.json"); err == nil {
+		t.Fatalf("Expected an error reading a missing config file")
+	}
+}
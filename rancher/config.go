@@ -1,29 +1,128 @@
 package rancher
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/logging"
 	rancher "github.com/rancher/go-rancher/client"
 	"github.com/raphink/go-rancher/catalog"
 )
 
 type Config struct {
 	*rancher.RancherClient
-	APIURL    string
-	AccessKey string
-	SecretKey string
+	// APIURL is the URL of the Rancher server, optionally including the API
+	// version. Once the client is created it's the URL of the API used.
+	APIURL string
+	// APIVersion is the version of the API used, set when the client is
+	// created.
+	APIVersion string
+	AccessKey  string
+	SecretKey  string
+	Timeout    time.Duration
+	CACerts    string
+	Insecure   bool
+	ClientCert string
+	ClientKey  string
+	MaxRetries int
+
+	// MaxConcurrentRequests and RequestsPerSecond limit the requests to the
+	// Rancher server when greater than zero.
+	MaxConcurrentRequests int
+	RequestsPerSecond     int
+
+	// SkipCredentialsValidation disables the checks of the settings and
+	// credentials when the provider is configured.
+	SkipCredentialsValidation bool
+
+	// EnvironmentID is the environment used by resources that don't set
+	// their own environment_id. It can also be given by EnvironmentName.
+	EnvironmentID   string
+	EnvironmentName string
+
+	// environmentScoped is set when the credentials are the API keys of an
+	// environment, which can only access that environment.
+	environmentScoped bool
+
+	// The generic client is created on first use when the validation of the
+	// credentials is skipped.
+	clientMu sync.Mutex
+
+	// Clients scoped to an environment and the catalog client are cached,
+	// since building one downloads the whole API schema.
+	clientsMu          sync.Mutex
+	environmentClients map[string]*cachedClient
+	catalogClient      *catalog.RancherClient
 }
 
-// Create creates a generic Rancher client
+type cachedClient struct {
+	sync.Mutex
+	client *rancher.RancherClient
+}
+
+// CreateClient creates the generic Rancher client. When the validation of the
+// credentials is skipped the client is instead created when first used, so no
+// request is made to the Rancher server until then.
 func (c *Config) CreateClient() error {
-	if c.APIURL == "" || c.AccessKey == "" || c.SecretKey == "" {
+	if c.SkipCredentialsValidation {
+		log.Printf("[INFO] Skipping the validation of the Rancher credentials")
 		return nil
 	}
 
+	_, err := c.Client()
+	return err
+}
+
+// Client returns the generic Rancher client, creating it if it wasn't yet
+func (c *Config) Client() (*rancher.RancherClient, error) {
+	c.clientMu.Lock()
+	defer c.clientMu.Unlock()
+
+	if c.RancherClient == nil {
+		if err := c.createClient(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.RancherClient, nil
+}
+
+func (c *Config) createClient() error {
+	if err := c.validateSettings(); err != nil {
+		return err
+	}
+
+	transport, err := c.roundTripper()
+	if err != nil {
+		return err
+	}
+
+	if err := registerTransport(c.APIURL, c.AccessKey, transport); err != nil {
+		return err
+	}
+
+	if err := c.resolveAPIVersion(); err != nil {
+		return err
+	}
+
+	if !c.SkipCredentialsValidation {
+		if err := c.validateCredentials(); err != nil {
+			return err
+		}
+	}
+
 	client, err := rancher.NewRancherClient(&rancher.ClientOpts{
 		Url:       c.APIURL,
 		AccessKey: c.AccessKey,
 		SecretKey: c.SecretKey,
+		Timeout:   c.clientTimeout(),
 	})
 	if err != nil {
 		return err
@@ -31,21 +130,264 @@ func (c *Config) CreateClient() error {
 
 	log.Printf("[INFO] Rancher Client configured for url: %s", c.APIURL)
 
+	if c.APIVersion == apiVersionV2Beta {
+		aliasStackType(client)
+	}
 	c.RancherClient = client
 
+	// The scope is detected first, since environment API keys can only
+	// resolve the name of their own environment.
+	if err := c.detectEnvironmentScope(); err != nil {
+		c.RancherClient = nil
+		return err
+	}
+
+	if err := c.resolveEnvironmentName(); err != nil {
+		c.RancherClient = nil
+		return err
+	}
+
+	return nil
+}
+
+// validateSettings checks that the settings needed to create a client are set
+func (c *Config) validateSettings() error {
+	var missing []string
+	if c.APIURL == "" {
+		missing = append(missing, "api_url")
+	}
+	if c.AccessKey == "" {
+		missing = append(missing, "access_key")
+	}
+	if c.SecretKey == "" {
+		missing = append(missing, "secret_key")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("Missing Rancher provider settings: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// validateCredentials makes an authenticated request to the API to check that
+// the credentials are accepted.
+func (c *Config) validateCredentials() error {
+	resp, err := c.getAPIRoot()
+	if err != nil {
+		return fmt.Errorf("Failed to connect to api_url %s: %s", c.APIURL, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("Invalid access_key or secret_key: the Rancher API at %s responded with HTTP %s", c.APIURL, resp.Status)
+	case resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("The access_key %s is not allowed to use the Rancher API at %s: HTTP %s", c.AccessKey, c.APIURL, resp.Status)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("Invalid api_url: the Rancher API at %s responded with HTTP %s", c.APIURL, resp.Status)
+	case resp.Header.Get("X-API-Schemas") == "":
+		return fmt.Errorf("Invalid api_url: %s is not a Rancher API", c.APIURL)
+	}
+
+	return nil
+}
+
+// resolveAPIVersion sets APIURL to the URL of the API version given in it.
+// URLs without a version use the v1 API, since the IDs of stacks differ
+// between versions and switching would orphan the stacks in the state.
+func (c *Config) resolveAPIVersion() error {
+	serverURL, version := normalizeAPIURL(c.APIURL)
+	if version == "" {
+		version = apiVersionV1
+	}
+
+	if err := validateAPIVersion(version); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Using Rancher API version: %s", version)
+
+	c.APIURL = serverURL + "/" + version
+	c.APIVersion = version
+
+	return nil
+}
+
+// resolveEnvironmentName sets the default environment from its name
+func (c *Config) resolveEnvironmentName() error {
+	if c.EnvironmentName == "" {
+		return nil
+	}
+
+	if c.environmentScoped {
+		return c.checkScopedEnvironmentName()
+	}
+
+	env, err := findEnvironmentByName(c.RancherClient, c.EnvironmentName)
+	if err != nil {
+		return fmt.Errorf("Failed to resolve environment_name: %s", err)
+	}
+
+	if c.EnvironmentID != "" && c.EnvironmentID != env.Id {
+		return fmt.Errorf("environment_name %q is environment %s, but environment_id is %s",
+			c.EnvironmentName, env.Id, c.EnvironmentID)
+	}
+
+	log.Printf("[INFO] Rancher default environment %q: %s", c.EnvironmentName, env.Id)
+
+	c.EnvironmentID = env.Id
+
+	return nil
+}
+
+// checkScopedEnvironmentName checks that the environment the credentials are
+// scoped to has the configured name.
+func (c *Config) checkScopedEnvironmentName() error {
+	env, err := c.Project.ById(c.EnvironmentID)
+	if err != nil {
+		return fmt.Errorf("Failed to resolve environment_name: %s", err)
+	}
+	if env == nil {
+		return fmt.Errorf("Failed to resolve environment_name: environment %s not found", c.EnvironmentID)
+	}
+
+	if env.Name != c.EnvironmentName {
+		return fmt.Errorf("environment_name %q doesn't match the environment %s (%q) the provider credentials are scoped to",
+			c.EnvironmentName, env.Id, env.Name)
+	}
+
+	return nil
+}
+
+// detectEnvironmentScope checks whether the credentials are the API keys of an
+// environment, in which case that environment becomes the default one.
+func (c *Config) detectEnvironmentScope() error {
+	accountID, err := c.apiAccountID()
+	if err != nil {
+		return err
+	}
+
+	if accountID == "" {
+		return nil
+	}
+
+	// Environment API keys authenticate as the environment itself, and may
+	// not be able to read projects at all.
+	if c.hasType("project") {
+		project, err := c.Project.ById(accountID)
+		if apiErr, ok := err.(*rancher.ApiError); ok && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+			log.Printf("[DEBUG] Rancher credentials can't read projects: %s", err)
+		} else if err != nil {
+			return err
+		} else if project == nil {
+			return nil
+		}
+	}
+
+	if c.EnvironmentID != "" && c.EnvironmentID != accountID {
+		return fmt.Errorf(
+			"The provider credentials are scoped to environment %s and can't use environment %s as default",
+			accountID, c.EnvironmentID)
+	}
+
+	log.Printf("[INFO] Rancher credentials are scoped to environment: %s", accountID)
+
+	c.EnvironmentID = accountID
+	c.environmentScoped = true
+
 	return nil
 }
 
+// hasType returns whether the API schemas of the credentials include a type
+func (c *Config) hasType(schemaType string) bool {
+	base, ok := c.RancherBaseClient.(*rancher.RancherBaseClientImpl)
+	if !ok {
+		return true
+	}
+
+	_, ok = base.Types[schemaType]
+	return ok
+}
+
+// apiAccountID returns the ID of the account the credentials belong to, as
+// reported by the API.
+func (c *Config) apiAccountID() (string, error) {
+	resp, err := c.getAPIRoot()
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	return resp.Header.Get("X-API-Account-Id"), nil
+}
+
+// getAPIRoot makes an authenticated request to the root of the API
+func (c *Config) getAPIRoot() (*http.Response, error) {
+	req, err := http.NewRequest("GET", c.APIURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.AccessKey, c.SecretKey)
+
+	client := &http.Client{Timeout: c.clientTimeout()}
+	return client.Do(req)
+}
+
+// EnvironmentClient returns a client scoped to the given environment. Clients
+// are built once per environment and reused afterwards.
 func (c *Config) EnvironmentClient(env string) (*rancher.RancherClient, error) {
-	if c.APIURL == "" || c.AccessKey == "" || c.SecretKey == "" {
-		return nil, nil
+	// The generic client also detects whether the credentials are scoped
+	if _, err := c.Client(); err != nil {
+		return nil, err
+	}
+
+	// Environment API keys already give a client scoped to their environment
+	if c.environmentScoped {
+		if env != c.EnvironmentID {
+			return nil, fmt.Errorf(
+				"The provider credentials are scoped to environment %s and can't access environment %s", c.EnvironmentID, env)
+		}
+		return c.RancherClient, nil
+	}
+
+	c.clientsMu.Lock()
+	if c.environmentClients == nil {
+		c.environmentClients = make(map[string]*cachedClient)
+	}
+	cached, ok := c.environmentClients[env]
+	if !ok {
+		cached = &cachedClient{}
+		c.environmentClients[env] = cached
 	}
+	c.clientsMu.Unlock()
 
+	// Only hold the lock of this environment while building its client, so
+	// clients for different environments can be built concurrently.
+	cached.Lock()
+	defer cached.Unlock()
+
+	if cached.client != nil {
+		return cached.client, nil
+	}
+
+	client, err := c.newEnvironmentClient(env)
+	if err != nil {
+		return nil, err
+	}
+
+	cached.client = client
+
+	return client, nil
+}
+
+func (c *Config) newEnvironmentClient(env string) (*rancher.RancherClient, error) {
 	url := c.APIURL + "/projects/" + env + "/schemas"
 	client, err := rancher.NewRancherClient(&rancher.ClientOpts{
 		Url:       url,
 		AccessKey: c.AccessKey,
 		SecretKey: c.SecretKey,
+		Timeout:   c.clientTimeout(),
 	})
 	if err != nil {
 		return nil, err
@@ -53,28 +395,56 @@ func (c *Config) EnvironmentClient(env string) (*rancher.RancherClient, error) {
 
 	log.Printf("[INFO] Rancher Client configured for url: %s", url)
 
+	if c.APIVersion == apiVersionV2Beta {
+		aliasStackType(client)
+	}
+
 	return client, nil
 }
 
+// RegistryClient returns a client for the environment of the given registry,
+// or nil if the registry does not exist.
 func (c *Config) RegistryClient(id string) (*rancher.RancherClient, error) {
-	reg, err := c.Registry.ById(id)
+	client, err := c.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	reg, err := client.Registry.ById(id)
 	if err != nil {
 		return nil, err
 	}
 
+	if reg == nil {
+		return nil, nil
+	}
+
 	return c.EnvironmentClient(reg.AccountId)
 }
 
+// CatalogClient returns a client for the catalog API. The client is built
+// once and reused afterwards.
 func (c *Config) CatalogClient() (*catalog.RancherClient, error) {
-	if c.APIURL == "" || c.AccessKey == "" || c.SecretKey == "" {
-		return nil, nil
+	// The generic client sets up the transport to the server
+	if _, err := c.Client(); err != nil {
+		return nil, err
 	}
 
-	url := c.APIURL + "-catalog/schemas"
+	c.clientsMu.Lock()
+	defer c.clientsMu.Unlock()
+
+	if c.catalogClient != nil {
+		return c.catalogClient, nil
+	}
+
+	// The catalog API has a single version for both v1 and v2-beta servers
+	serverURL, _ := normalizeAPIURL(c.APIURL)
+	url := serverURL + "/v1-catalog/schemas"
 	client, err := catalog.NewRancherClient(&catalog.ClientOpts{
 		Url:       url,
 		AccessKey: c.AccessKey,
 		SecretKey: c.SecretKey,
+		Timeout:   c.clientTimeout(),
 	})
 	if err != nil {
 		return nil, err
@@ -82,5 +452,72 @@ func (c *Config) CatalogClient() (*catalog.RancherClient, error) {
 
 	log.Printf("[INFO] Rancher Catalog Client configured for url: %s", url)
 
+	c.catalogClient = client
+
 	return client, nil
 }
+
+// roundTripper returns the chain of transports the requests to the Rancher
+// server go through: retries, then rate limiting, timeout and debug logging
+// of every attempt.
+func (c *Config) roundTripper() (http.RoundTripper, error) {
+	transport, err := c.httpTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	var rt http.RoundTripper = transport
+	if logging.IsDebugOrHigher() {
+		rt = newLogTransport(rt)
+	}
+
+	if c.Timeout > 0 {
+		rt = &attemptTimeoutTransport{next: rt, timeout: c.Timeout}
+	}
+
+	if c.MaxConcurrentRequests > 0 || c.RequestsPerSecond > 0 {
+		rt = newRateLimitTransport(rt, c.MaxConcurrentRequests, c.RequestsPerSecond)
+	}
+
+	return newRetryTransport(rt, c.MaxRetries), nil
+}
+
+// clientTimeout returns the timeout of the clients, which covers all the
+// attempts of a request, each limited to Timeout.
+func (c *Config) clientTimeout() time.Duration {
+	return retryClientTimeout(c.Timeout, c.MaxRetries)
+}
+
+// httpTransport returns the transport used for all the requests to the
+// Rancher server, configured with the TLS settings of the provider.
+func (c *Config) httpTransport() (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.Insecure,
+	}
+
+	if c.CACerts != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CACerts)) {
+			return nil, fmt.Errorf("Failed to parse ca_certs: no PEM certificate found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load client_cert and client_key: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		Dial: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).Dial,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}, nil
+}
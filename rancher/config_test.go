@@ -0,0 +1,292 @@
+package rancher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConfigHTTPTransport(t *testing.T) {
+	config := &Config{Insecure: true}
+
+	transport, err := config.httpTransport()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("Expected TLS verification to be skipped")
+	}
+
+	if transport.TLSClientConfig.RootCAs != nil {
+		t.Fatal("Expected the system CA certificates to be used")
+	}
+}
+
+func TestConfigHTTPTransport_invalidCACerts(t *testing.T) {
+	config := &Config{CACerts: "not a certificate"}
+
+	if _, err := config.httpTransport(); err == nil {
+		t.Fatal("Expected an error for invalid ca_certs")
+	}
+}
+
+func TestConfigHTTPTransport_invalidClientCert(t *testing.T) {
+	config := &Config{ClientCert: "not a certificate", ClientKey: "not a key"}
+
+	if _, err := config.httpTransport(); err == nil {
+		t.Fatal("Expected an error for an invalid client certificate")
+	}
+}
+
+func TestRegisterTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// Restore the transports once done, since they are global
+	originalTransport := http.DefaultTransport
+	defer func() {
+		transportsMu.Lock()
+		http.DefaultTransport = originalTransport
+		transports = map[string]http.RoundTripper{}
+		transportsMu.Unlock()
+	}()
+
+	// The test server certificate is self-signed, so requests only succeed
+	// through the transport registered for it.
+	if _, err := http.Get(server.URL); err == nil {
+		t.Fatal("Expected the default transport to reject the self-signed certificate")
+	}
+
+	config := &Config{Insecure: true}
+	transport, err := config.httpTransport()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := registerTransport(server.URL+"/v1", "access", transport); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	req.SetBasicAuth("access", "secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	// Requests with other credentials don't use the registered transport
+	req.SetBasicAuth("other", "secret")
+	if _, err := http.DefaultClient.Do(req); err == nil {
+		t.Fatal("Expected the transport of other credentials to reject the self-signed certificate")
+	}
+}
+
+func TestConfigEnvironmentClient_cached(t *testing.T) {
+	requests := map[string]int{}
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+
+		w.Header().Set("X-API-Schemas", "http://"+r.Host+r.URL.Path)
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		APIURL:    server.URL + "/v1",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for _, env := range []string{"1a5", "1a6"} {
+			wg.Add(1)
+			go func(env string) {
+				defer wg.Done()
+				if _, err := config.EnvironmentClient(env); err != nil {
+					t.Errorf("err: %s", err)
+				}
+			}(env)
+		}
+	}
+	wg.Wait()
+
+	for _, path := range []string{"/v1/projects/1a5/schemas", "/v1/projects/1a6/schemas"} {
+		if requests[path] != 1 {
+			t.Fatalf("Bad requests to %s: %d should be: %d", path, requests[path], 1)
+		}
+	}
+}
+
+func TestConfigCreateClient_environmentScoped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-API-Account-Id", "1a5")
+		w.Header().Set("X-API-Schemas", "http://"+r.Host+"/v1/schemas")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		APIURL:    server.URL + "/v1",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+
+	if err := config.CreateClient(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if config.EnvironmentID != "1a5" {
+		t.Fatalf("Bad environment: %s should be: %s", config.EnvironmentID, "1a5")
+	}
+
+	client, err := config.EnvironmentClient("1a5")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client != config.RancherClient {
+		t.Fatalf("Environment client should be the provider client")
+	}
+
+	if _, err := config.EnvironmentClient("1a6"); err == nil {
+		t.Fatalf("Expected an error accessing another environment")
+	}
+}
+
+func TestConfigCreateClient_environmentScopedConflict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-API-Account-Id", "1a5")
+		w.Header().Set("X-API-Schemas", "http://"+r.Host+"/v1/schemas")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		APIURL:        server.URL + "/v1",
+		AccessKey:     "access",
+		SecretKey:     "secret",
+		EnvironmentID: "1a6",
+	}
+
+	if err := config.CreateClient(); err == nil {
+		t.Fatalf("Expected an error using another environment as default")
+	}
+}
+
+func TestConfigCreateClient_missingSettings(t *testing.T) {
+	config := &Config{
+		APIURL: "http://rancher.example.com",
+	}
+
+	err := config.CreateClient()
+	if err == nil || !strings.Contains(err.Error(), "access_key, secret_key") {
+		t.Fatalf("Expected an error naming the missing settings, got: %v", err)
+	}
+
+	config.SkipCredentialsValidation = true
+	if err := config.CreateClient(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The settings are checked when the client is first used instead
+	_, err = config.Client()
+	if err == nil || !strings.Contains(err.Error(), "access_key, secret_key") {
+		t.Fatalf("Expected an error naming the missing settings, got: %v", err)
+	}
+}
+
+func TestConfigCreateClient_skipCredentialsValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-API-Schemas", "http://"+r.Host+"/v1/schemas")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		APIURL:                    server.URL,
+		AccessKey:                 "access",
+		SecretKey:                 "secret",
+		EnvironmentName:           "Default",
+		SkipCredentialsValidation: true,
+	}
+
+	if err := config.CreateClient(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if requests != 0 {
+		t.Fatalf("Bad requests: %d should be: %d", requests, 0)
+	}
+}
+
+func TestConfigCreateClient_invalidCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-API-Schemas", "http://"+r.Host+"/v1/schemas")
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		APIURL:    server.URL + "/v1",
+		AccessKey: "access",
+		SecretKey: "invalid",
+	}
+
+	err := config.CreateClient()
+	if err == nil || !strings.Contains(err.Error(), "401 Unauthorized") {
+		t.Fatalf("Expected an error with the HTTP status, got: %v", err)
+	}
+}
+
+func TestConfigCreateClient_environmentScopedName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-API-Account-Id", "1a5")
+		w.Header().Set("X-API-Schemas", "http://"+r.Host+"/v1/schemas")
+		switch r.URL.Path {
+		case "/v1/schemas":
+			w.Write([]byte(`{"data":[{"id":"project","links":{"collection":"http://` + r.Host + `/v1/projects"},"resourceMethods":["GET"]}]}`))
+		case "/v1/projects/1a5":
+			w.Write([]byte(`{"id":"1a5","name":"Default"}`))
+		case "/v1/projects":
+			t.Errorf("Environment API keys should not list environments")
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{
+		APIURL:          server.URL + "/v1",
+		AccessKey:       "access",
+		SecretKey:       "secret",
+		EnvironmentName: "Default",
+	}
+
+	if err := config.CreateClient(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if config.EnvironmentID != "1a5" {
+		t.Fatalf("Bad environment: %s should be: %s", config.EnvironmentID, "1a5")
+	}
+
+	config = &Config{
+		APIURL:          server.URL + "/v1",
+		AccessKey:       "access",
+		SecretKey:       "secret",
+		EnvironmentName: "Production",
+	}
+
+	if err := config.CreateClient(); err == nil {
+		t.Fatalf("Expected an error for the name of another environment")
+	}
+}
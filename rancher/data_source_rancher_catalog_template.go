@@ -0,0 +1,245 @@
+package rancher
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/raphink/go-rancher/catalog"
+)
+
+func dataSourceRancherCatalogTemplate() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRancherCatalogTemplateRead,
+
+		Schema: map[string]*schema.Schema{
+			"template_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"rancher_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"category": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"default_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"versions": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"latest_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_version_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"files": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"questions": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"variable": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"required": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"default": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"options": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// catalogTemplateVersion is a catalog.TemplateVersion with its questions
+// decoded as catalog.Question, which the generated type lacks.
+type catalogTemplateVersion struct {
+	catalog.TemplateVersion
+	MaximumRancherVersion string             `json:"maximumRancherVersion,omitempty"`
+	Questions             []catalog.Question `json:"questions,omitempty"`
+}
+
+func dataSourceRancherCatalogTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	catalogClient, err := config.CatalogClient()
+	if err != nil {
+		return err
+	}
+
+	templateID := d.Get("template_id").(string)
+	log.Printf("[INFO] Looking up Catalog Template: %s", templateID)
+
+	template, err := catalogClient.Template.ById(templateID)
+	if err != nil {
+		return fmt.Errorf("Failed to get catalog template %s: %s", templateID, err)
+	}
+	if template == nil || template.Id == "" {
+		return fmt.Errorf("Catalog template %s not found", templateID)
+	}
+
+	rancherVersion := d.Get("rancher_version").(string)
+	if rancherVersion == "" {
+		client, err := config.Client()
+		if err != nil {
+			return err
+		}
+
+		setting, err := client.Setting.ById("rancher.server.version")
+		if err != nil {
+			return fmt.Errorf("Failed to get rancher server version: %s", err)
+		}
+		if setting != nil {
+			rancherVersion = setting.Value
+		}
+	}
+
+	versions := make(map[string]interface{})
+	var latest *catalogTemplateVersion
+	latestRevision := -1
+	for v, link := range template.VersionLinks {
+		versionID := templateVersionIDFromLink(fmt.Sprintf("%v", link))
+		versions[v] = versionID
+
+		var templateVersion catalogTemplateVersion
+		if err := catalogClient.ById("templateVersion", versionID, &templateVersion); err != nil {
+			return fmt.Errorf("Failed to get catalog template version %s: %s", versionID, err)
+		}
+
+		if !isCompatibleRancherVersion(rancherVersion, templateVersion.MinimumRancherVersion, templateVersion.MaximumRancherVersion) {
+			log.Printf("[DEBUG] Catalog template version %s is not compatible with rancher %s", versionID, rancherVersion)
+			continue
+		}
+
+		revision := templateVersionRevision(versionID)
+		if revision > latestRevision {
+			latestRevision = revision
+			tv := templateVersion
+			latest = &tv
+		}
+	}
+
+	if latest == nil {
+		return fmt.Errorf("Catalog template %s has no version compatible with rancher %s", templateID, rancherVersion)
+	}
+
+	files := make(map[string]interface{})
+	for name, content := range latest.Files {
+		files[name] = fmt.Sprintf("%v", content)
+	}
+
+	questions := make([]map[string]interface{}, 0, len(latest.Questions))
+	for _, q := range latest.Questions {
+		questions = append(questions, map[string]interface{}{
+			"variable":    q.Variable,
+			"label":       q.Label,
+			"description": q.Description,
+			"type":        q.Type,
+			"required":    q.Required,
+			"default":     q.Default,
+			"options":     q.Options,
+		})
+	}
+
+	d.SetId(template.Id)
+	d.Set("rancher_version", rancherVersion)
+	d.Set("name", template.Name)
+	d.Set("description", template.Description)
+	d.Set("category", template.Category)
+	d.Set("default_version", template.DefaultVersion)
+	d.Set("versions", versions)
+	d.Set("latest_version", latest.Version)
+	d.Set("latest_version_id", latest.Id)
+	d.Set("files", files)
+	d.Set("questions", questions)
+
+	return nil
+}
+
+// templateVersionIDFromLink returns the template version ID (catalog:template:revision)
+// a version link points to.
+func templateVersionIDFromLink(link string) string {
+	link = strings.TrimRight(link, "/")
+	return link[strings.LastIndex(link, "/")+1:]
+}
+
+// templateVersionRevision returns the revision number of a template version
+// ID, which grows with every new version of the template.
+func templateVersionRevision(versionID string) int {
+	revision, err := strconv.Atoi(versionID[strings.LastIndex(versionID, ":")+1:])
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+// isCompatibleRancherVersion returns whether the rancher version is within the
+// given bounds. Versions that can't be parsed, like development builds, are
+// considered compatible.
+func isCompatibleRancherVersion(rancherVersion string, minimum string, maximum string) bool {
+	current, err := parseRancherVersion(rancherVersion)
+	if err != nil {
+		return true
+	}
+
+	if min, err := parseRancherVersion(minimum); err == nil && current.LessThan(min) {
+		return false
+	}
+
+	if max, err := parseRancherVersion(maximum); err == nil && current.GreaterThan(max) {
+		return false
+	}
+
+	return true
+}
+
+// parseRancherVersion parses a rancher version like v1.1.3
+func parseRancherVersion(v string) (*version.Version, error) {
+	return version.NewVersion(strings.TrimPrefix(v, "v"))
+}
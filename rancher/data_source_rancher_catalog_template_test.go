@@ -0,0 +1,53 @@
+package rancher
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRancherCatalogTemplateDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRancherCatalogTemplateDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rancher_catalog_template.route53", "id", "library:route53"),
+					resource.TestMatchResourceAttr("data.rancher_catalog_template.route53", "latest_version_id", regexp.MustCompile("^library:route53:[0-9]+$")),
+					resource.TestMatchResourceAttr("data.rancher_catalog_template.route53", "files.docker-compose.yml", regexp.MustCompile("route53")),
+				),
+			},
+		},
+	})
+}
+
+func TestIsCompatibleRancherVersion(t *testing.T) {
+	cases := []struct {
+		Version  string
+		Minimum  string
+		Maximum  string
+		Expected bool
+	}{
+		{"v1.1.3", "", "", true},
+		{"v1.1.3", "v1.0.0", "", true},
+		{"v1.1.3", "v1.2.0-pre1", "", false},
+		{"v1.1.3", "", "v1.1.99", true},
+		{"v1.2.0", "", "v1.1.99", false},
+		{"dev", "v1.2.0", "", true},
+	}
+
+	for _, tc := range cases {
+		if got := isCompatibleRancherVersion(tc.Version, tc.Minimum, tc.Maximum); got != tc.Expected {
+			t.Fatalf("Bad compatibility of %s with [%s, %s]: %t should be: %t", tc.Version, tc.Minimum, tc.Maximum, got, tc.Expected)
+		}
+	}
+}
+
+const testAccRancherCatalogTemplateDataSourceConfig = `
+data "rancher_catalog_template" "route53" {
+	template_id = "library:route53"
+}
+`
@@ -0,0 +1,147 @@
+package rancher
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rancher "github.com/rancher/go-rancher/client"
+)
+
+func dataSourceRancherCertificate() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRancherCertificateRead,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"cn"},
+			},
+			"cn": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"name"},
+			},
+			"min_days_remaining": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fingerprint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expires_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"issuer": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subject_alternative_names": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// certificateTimeLayouts are the formats Rancher uses for certificate dates
+var certificateTimeLayouts = []string{
+	time.RFC3339,
+	time.UnixDate,
+	"Mon Jan 02 15:04:05 MST 2006",
+}
+
+func dataSourceRancherCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := environmentClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	cn := d.Get("cn").(string)
+	if name == "" && cn == "" {
+		return fmt.Errorf("One of name or cn must be set")
+	}
+
+	log.Printf("[INFO] Looking up Certificate: name=%q cn=%q", name, cn)
+
+	opts := rancher.NewListOpts()
+	if name != "" {
+		opts.Filters["name"] = name
+	} else {
+		opts.Filters["cN"] = cn
+	}
+
+	certs, err := client.Certificate.List(opts)
+	if err != nil {
+		return fmt.Errorf("Failed to list certificates: %s", err)
+	}
+
+	var found []rancher.Certificate
+	for _, cert := range certs.Data {
+		if isRemovedState(cert.State) {
+			continue
+		}
+		if (name != "" && cert.Name == name) || (name == "" && cert.CN == cn) {
+			found = append(found, cert)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return fmt.Errorf("Certificate not found")
+	case 1:
+	default:
+		return fmt.Errorf("Found %d certificates, expected exactly one", len(found))
+	}
+
+	cert := found[0]
+	log.Printf("[INFO] Certificate ID: %s", cert.Id)
+
+	if days := d.Get("min_days_remaining").(int); days > 0 {
+		expiresAt, err := parseCertificateTime(cert.ExpiresAt)
+		if err != nil {
+			return err
+		}
+
+		if expiresAt.Before(time.Now().Add(time.Duration(days) * 24 * time.Hour)) {
+			return fmt.Errorf("Certificate %s (%s) expires at %s, within %d days", cert.Name, cert.Id, cert.ExpiresAt, days)
+		}
+	}
+
+	d.SetId(cert.Id)
+	d.Set("name", cert.Name)
+	d.Set("cn", cert.CN)
+	d.Set("description", cert.Description)
+	d.Set("fingerprint", cert.CertFingerprint)
+	d.Set("expires_at", cert.ExpiresAt)
+	d.Set("issuer", cert.Issuer)
+	d.Set("subject_alternative_names", cert.SubjectAlternativeNames)
+
+	return nil
+}
+
+func parseCertificateTime(value string) (time.Time, error) {
+	for _, layout := range certificateTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("Failed to parse certificate date: %s", value)
+}
@@ -0,0 +1,47 @@
+package rancher
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRancherCertificateDataSource_missing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccRancherCertificateDataSourceMissingConfig,
+				ExpectError: regexp.MustCompile("Certificate not found"),
+			},
+		},
+	})
+}
+
+func TestParseCertificateTime(t *testing.T) {
+	expected := time.Date(2017, time.November, 19, 20, 44, 38, 0, time.UTC)
+
+	for _, value := range []string{"2017-11-19T20:44:38Z", "Sun Nov 19 20:44:38 UTC 2017"} {
+		got, err := parseCertificateTime(value)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if !got.Equal(expected) {
+			t.Fatalf("Bad time for %s: %s should be: %s", value, got, expected)
+		}
+	}
+
+	if _, err := parseCertificateTime("soon"); err == nil {
+		t.Fatal("Expected an error for an invalid date")
+	}
+}
+
+const testAccRancherCertificateDataSourceMissingConfig = `
+data "rancher_certificate" "missing" {
+	environment_id = "1a5"
+	name = "terraform-acc-test-missing"
+}
+`
@@ -0,0 +1,86 @@
+package rancher
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rancher "github.com/rancher/go-rancher/client"
+)
+
+func dataSourceRancherEnvironment() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRancherEnvironmentRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"orchestration": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRancherEnvironmentRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Looking up Environment: %s", name)
+
+	env, err := findEnvironmentByName(client, name)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Environment ID: %s", env.Id)
+
+	d.SetId(env.Id)
+	d.Set("orchestration", GetActiveOrchestration(env))
+	d.Set("description", env.Description)
+	d.Set("state", env.State)
+
+	return nil
+}
+
+// findEnvironmentByName returns the only environment with the given name that
+// has not been removed.
+func findEnvironmentByName(client *rancher.RancherClient, name string) (*rancher.Project, error) {
+	opts := rancher.NewListOpts()
+	opts.Filters["name"] = name
+
+	envs, err := client.Project.List(opts)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list environments: %s", err)
+	}
+
+	var found []rancher.Project
+	for _, env := range envs.Data {
+		if env.Name == name && !isRemovedState(env.State) {
+			found = append(found, env)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("Environment with name %q not found", name)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("Found %d environments with name %q, expected exactly one", len(found), name)
+	}
+}
@@ -0,0 +1,47 @@
+package rancher
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRancherEnvironmentDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRancherEnvironmentDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rancher_environment.foo", "orchestration", "cattle"),
+					resource.TestCheckResourceAttr("data.rancher_environment.foo", "description", "Terraform acc test group - data source"),
+					resource.TestCheckResourceAttr("data.rancher_environment.foo", "state", "active"),
+				),
+			},
+			resource.TestStep{
+				Config:      testAccRancherEnvironmentDataSourceMissingConfig,
+				ExpectError: regexp.MustCompile("not found"),
+			},
+		},
+	})
+}
+
+const testAccRancherEnvironmentDataSourceConfig = `
+resource "rancher_environment" "foo" {
+	name = "foo-data-source"
+	description = "Terraform acc test group - data source"
+	orchestration = "cattle"
+}
+
+data "rancher_environment" "foo" {
+	name = "${rancher_environment.foo.name}"
+}
+`
+
+const testAccRancherEnvironmentDataSourceMissingConfig = `
+data "rancher_environment" "missing" {
+	name = "terraform-acc-test-missing"
+}
+`
@@ -0,0 +1,202 @@
+package rancher
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rancher "github.com/rancher/go-rancher/client"
+)
+
+func dataSourceRancherHosts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRancherHostsRead,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"filters": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"label_selectors": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"hosts": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hostname": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"labels": &schema.Schema{
+							Type:     schema.TypeMap,
+							Computed: true,
+						},
+						"public_ip": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"agent_state": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// hostLabelSelector matches a host label against a value, or only checks
+// that the label exists when the operator is empty.
+type hostLabelSelector struct {
+	Label    string
+	Operator string
+	Value    string
+}
+
+func dataSourceRancherHostsRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := environmentClient(d, meta)
+	if err != nil {
+		return err
+	}
+	envID := d.Get("environment_id").(string)
+
+	var selectors []hostLabelSelector
+	for _, s := range d.Get("label_selectors").([]interface{}) {
+		selector, err := parseHostLabelSelector(s.(string))
+		if err != nil {
+			return err
+		}
+		selectors = append(selectors, selector)
+	}
+
+	opts := rancher.NewListOpts()
+	for k, v := range d.Get("filters").(map[string]interface{}) {
+		opts.Filters[k] = v
+	}
+
+	log.Printf("[INFO] Listing Hosts of Environment: %s", envID)
+
+	hosts, err := client.Host.List(opts)
+	if err != nil {
+		return fmt.Errorf("Failed to list hosts: %s", err)
+	}
+
+	var result []map[string]interface{}
+	for _, host := range hosts.Data {
+		if isRemovedState(host.State) || !matchHostLabelSelectors(host.Labels, selectors) {
+			continue
+		}
+
+		publicIP, err := hostPublicIP(client, &host)
+		if err != nil {
+			return err
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":          host.Id,
+			"hostname":    host.Hostname,
+			"labels":      host.Labels,
+			"public_ip":   publicIP,
+			"state":       host.State,
+			"agent_state": host.AgentState,
+		})
+	}
+
+	log.Printf("[INFO] Found %d Hosts", len(result))
+
+	d.SetId(envID)
+	d.Set("hosts", result)
+
+	return nil
+}
+
+// parseHostLabelSelector parses selectors of the form "label = value",
+// "label != value", "label exists" or just "label" to check that the label
+// exists.
+func parseHostLabelSelector(s string) (hostLabelSelector, error) {
+	for _, op := range []string{"!=", "="} {
+		if i := strings.Index(s, op); i >= 0 {
+			selector := hostLabelSelector{
+				Label:    strings.TrimSpace(s[:i]),
+				Operator: op,
+				Value:    strings.TrimSpace(s[i+len(op):]),
+			}
+			if selector.Label == "" {
+				return selector, fmt.Errorf("Invalid label selector %q: missing label", s)
+			}
+			return selector, nil
+		}
+	}
+
+	fields := strings.Fields(s)
+	switch {
+	case len(fields) == 0:
+		return hostLabelSelector{}, fmt.Errorf("Invalid label selector %q: missing label", s)
+	case len(fields) == 1, len(fields) == 2 && fields[1] == "exists":
+		return hostLabelSelector{Label: fields[0]}, nil
+	default:
+		return hostLabelSelector{}, fmt.Errorf(
+			"Invalid label selector %q: must be one of label = value, label != value, label exists or label", s)
+	}
+}
+
+func matchHostLabelSelectors(labels map[string]interface{}, selectors []hostLabelSelector) bool {
+	for _, selector := range selectors {
+		value, ok := labels[selector.Label]
+
+		switch selector.Operator {
+		case "=":
+			if !ok || fmt.Sprintf("%v", value) != selector.Value {
+				return false
+			}
+		case "!=":
+			if ok && fmt.Sprintf("%v", value) == selector.Value {
+				return false
+			}
+		default:
+			if !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func hostPublicIP(client *rancher.RancherClient, host *rancher.Host) (string, error) {
+	if _, ok := host.Links["ipAddresses"]; !ok {
+		return "", nil
+	}
+
+	var ips rancher.IpAddressCollection
+	if err := client.GetLink(host.Resource, "ipAddresses", &ips); err != nil {
+		return "", fmt.Errorf("Failed to get IP addresses of host %s: %s", host.Id, err)
+	}
+
+	for _, ip := range ips.Data {
+		if !isRemovedState(ip.State) && ip.Address != "" {
+			return ip.Address, nil
+		}
+	}
+
+	return "", nil
+}
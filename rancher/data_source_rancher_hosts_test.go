@@ -0,0 +1,75 @@
+package rancher
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRancherHostsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRancherHostsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rancher_hosts.foo", "id", "1a5"),
+					resource.TestCheckResourceAttr("data.rancher_hosts.foo", "hosts.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestMatchHostLabelSelectors(t *testing.T) {
+	labels := map[string]interface{}{
+		"role": "db",
+		"az":   "a",
+	}
+
+	cases := []struct {
+		Selectors []string
+		Expected  bool
+	}{
+		{[]string{}, true},
+		{[]string{"role = db"}, true},
+		{[]string{"role=web"}, false},
+		{[]string{"role != web", "az"}, true},
+		{[]string{"az != a"}, false},
+		{[]string{"gpu"}, false},
+		{[]string{"role exists"}, true},
+		{[]string{"gpu exists"}, false},
+		{[]string{"gpu != yes"}, true},
+	}
+
+	for _, tc := range cases {
+		var selectors []hostLabelSelector
+		for _, s := range tc.Selectors {
+			selector, err := parseHostLabelSelector(s)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			selectors = append(selectors, selector)
+		}
+
+		if got := matchHostLabelSelectors(labels, selectors); got != tc.Expected {
+			t.Fatalf("Bad match for %v: %t should be: %t", tc.Selectors, got, tc.Expected)
+		}
+	}
+
+	if _, err := parseHostLabelSelector(" = db"); err == nil {
+		t.Fatal("Expected an error for a selector without label")
+	}
+
+	if _, err := parseHostLabelSelector("role is db"); err == nil {
+		t.Fatal("Expected an error for a selector with unknown syntax")
+	}
+}
+
+const testAccRancherHostsDataSourceConfig = `
+data "rancher_hosts" "foo" {
+	environment_id = "1a5"
+	label_selectors = ["role = db"]
+}
+`
@@ -0,0 +1,97 @@
+package rancher
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rancher "github.com/rancher/go-rancher/client"
+)
+
+func dataSourceRancherRegistry() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRancherRegistryRead,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"server_address"},
+			},
+			"server_address": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"name"},
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRancherRegistryRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := environmentClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	serverAddress := d.Get("server_address").(string)
+	if name == "" && serverAddress == "" {
+		return fmt.Errorf("One of name or server_address must be set")
+	}
+
+	log.Printf("[INFO] Looking up Registry: name=%q server_address=%q", name, serverAddress)
+
+	opts := rancher.NewListOpts()
+	if name != "" {
+		opts.Filters["name"] = name
+	} else {
+		opts.Filters["serverAddress"] = serverAddress
+	}
+
+	registries, err := client.Registry.List(opts)
+	if err != nil {
+		return fmt.Errorf("Failed to list registries: %s", err)
+	}
+
+	var found []rancher.Registry
+	for _, registry := range registries.Data {
+		if isRemovedState(registry.State) {
+			continue
+		}
+		if (name != "" && registry.Name == name) || (name == "" && registry.ServerAddress == serverAddress) {
+			found = append(found, registry)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return fmt.Errorf("Registry not found")
+	case 1:
+	default:
+		return fmt.Errorf("Found %d registries, expected exactly one", len(found))
+	}
+
+	registry := found[0]
+	log.Printf("[INFO] Registry ID: %s", registry.Id)
+
+	d.SetId(registry.Id)
+	d.Set("name", registry.Name)
+	d.Set("server_address", registry.ServerAddress)
+	d.Set("description", registry.Description)
+	d.Set("state", registry.State)
+
+	return nil
+}
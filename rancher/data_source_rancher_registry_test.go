@@ -0,0 +1,37 @@
+package rancher
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRancherRegistryDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRancherRegistryDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rancher_registry.foo", "name", "foo-data-source"),
+					resource.TestCheckResourceAttr("data.rancher_registry.foo", "description", "registry test - data source"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRancherRegistryDataSourceConfig = `
+resource "rancher_registry" "foo" {
+	name = "foo-data-source"
+	description = "registry test - data source"
+	server_address = "http://data.source.com:8080"
+	environment_id = "1a5"
+}
+
+data "rancher_registry" "foo" {
+	environment_id = "${rancher_registry.foo.environment_id}"
+	server_address = "${rancher_registry.foo.server_address}"
+}
+`
@@ -0,0 +1,142 @@
+package rancher
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rancher "github.com/rancher/go-rancher/client"
+)
+
+func dataSourceRancherService() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRancherServiceRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateServiceName,
+			},
+			"environment_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"stack_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kind": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"image": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"scale": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"vip": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fqdn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"public_endpoints": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"health_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRancherServiceRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := environmentClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Looking up Service: %s", name)
+
+	// The schema doesn't validate the name when it's interpolated
+	if _, errs := validateServiceName(name, "name"); len(errs) > 0 {
+		return errs[0]
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	stackName, serviceName := parts[0], parts[1]
+
+	stack, err := findStackByName(client, stackName)
+	if err != nil {
+		return err
+	}
+
+	opts := rancher.NewListOpts()
+	opts.Filters["name"] = serviceName
+
+	services, err := listStackServices(client, stack.Id, opts)
+	if err != nil {
+		return fmt.Errorf("Failed to list services of stack %s: %s", stackName, err)
+	}
+
+	var found []rancher.Service
+	for _, service := range services {
+		if service.Name == serviceName {
+			found = append(found, service)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return fmt.Errorf("Service %q not found", name)
+	case 1:
+	default:
+		return fmt.Errorf("Found %d services named %q, expected exactly one", len(found), name)
+	}
+
+	service := found[0]
+	log.Printf("[INFO] Service ID: %s", service.Id)
+
+	var image string
+	if service.LaunchConfig != nil {
+		image = strings.TrimPrefix(service.LaunchConfig.ImageUuid, "docker:")
+	}
+
+	d.SetId(service.Id)
+	d.Set("stack_id", stack.Id)
+	d.Set("kind", service.Kind)
+	d.Set("image", image)
+	d.Set("scale", int(service.Scale))
+	d.Set("vip", service.Vip)
+	d.Set("fqdn", service.Fqdn)
+	d.Set("public_endpoints", flattenPublicEndpoints(service.PublicEndpoints))
+	d.Set("state", service.State)
+	d.Set("health_state", service.HealthState)
+
+	return nil
+}
+
+func validateServiceName(v interface{}, k string) (ws []string, errors []error) {
+	parts := strings.Split(v.(string), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be of the form stack_name/service_name, got: %s", k, v))
+	}
+	return
+}
@@ -0,0 +1,38 @@
+package rancher
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRancherServiceDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRancherServiceDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rancher_service.web", "kind", "service"),
+					resource.TestCheckResourceAttr("data.rancher_service.web", "image", "nginx"),
+					resource.TestCheckResourceAttr("data.rancher_service.web", "scale", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRancherServiceDataSourceConfig = `
+resource "rancher_stack" "foo" {
+	name = "foo-service-data-source"
+	environment_id = "1a5"
+	docker_compose = "web: { image: nginx }"
+	rancher_compose = "web: { scale: 1 }"
+}
+
+data "rancher_service" "web" {
+	name = "${rancher_stack.foo.name}/web"
+	environment_id = "${rancher_stack.foo.environment_id}"
+}
+`
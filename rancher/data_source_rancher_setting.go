@@ -0,0 +1,79 @@
+package rancher
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rancher "github.com/rancher/go-rancher/client"
+)
+
+func dataSourceRancherSetting() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRancherSettingRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"value": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"active_value": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRancherSettingRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*Config).Client()
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Looking up Setting: %s", name)
+
+	setting, err := client.Setting.ById(name)
+	if err != nil {
+		return fmt.Errorf("Failed to get setting %s: %s", name, err)
+	}
+
+	// Settings are usually addressable by name, but fall back to listing them
+	if setting == nil || setting.Name != name {
+		opts := rancher.NewListOpts()
+		opts.Filters["name"] = name
+
+		settings, err := client.Setting.List(opts)
+		if err != nil {
+			return fmt.Errorf("Failed to list settings: %s", err)
+		}
+
+		setting = nil
+		for i := range settings.Data {
+			if settings.Data[i].Name == name {
+				setting = &settings.Data[i]
+				break
+			}
+		}
+	}
+
+	if setting == nil {
+		return fmt.Errorf("Setting %q not found", name)
+	}
+
+	d.SetId(setting.Name)
+	d.Set("value", setting.Value)
+	d.Set("active_value", setting.ActiveValue)
+	d.Set("source", setting.Source)
+
+	return nil
+}
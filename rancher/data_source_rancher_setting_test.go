@@ -0,0 +1,40 @@
+package rancher
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRancherSettingDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRancherSettingDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rancher_setting.version", "id", "rancher.server.version"),
+					resource.TestMatchResourceAttr("data.rancher_setting.version", "value", regexp.MustCompile(".+")),
+				),
+			},
+			resource.TestStep{
+				Config:      testAccRancherSettingDataSourceMissingConfig,
+				ExpectError: regexp.MustCompile("not found"),
+			},
+		},
+	})
+}
+
+const testAccRancherSettingDataSourceConfig = `
+data "rancher_setting" "version" {
+	name = "rancher.server.version"
+}
+`
+
+const testAccRancherSettingDataSourceMissingConfig = `
+data "rancher_setting" "missing" {
+	name = "terraform.acc.test.missing"
+}
+`
@@ -0,0 +1,157 @@
+package rancher
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rancher "github.com/rancher/go-rancher/client"
+)
+
+func dataSourceRancherStack() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRancherStackRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"environment_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"docker_compose": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rancher_compose": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"environment": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"external_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"health_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"services": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kind": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fqdn": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_endpoints": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRancherStackRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := environmentClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Looking up Stack: %s", name)
+
+	stack, err := findStackByName(client, name)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Stack ID: %s", stack.Id)
+
+	services, err := listStackServices(client, stack.Id, rancher.NewListOpts())
+	if err != nil {
+		return fmt.Errorf("Failed to list services of stack %s: %s", name, err)
+	}
+
+	var stackServices []map[string]interface{}
+	for _, service := range services {
+		stackServices = append(stackServices, map[string]interface{}{
+			"id":               service.Id,
+			"name":             service.Name,
+			"kind":             service.Kind,
+			"fqdn":             service.Fqdn,
+			"public_endpoints": flattenPublicEndpoints(service.PublicEndpoints),
+		})
+	}
+
+	d.SetId(stack.Id)
+	d.Set("description", stack.Description)
+	d.Set("docker_compose", stack.DockerCompose)
+	d.Set("rancher_compose", stack.RancherCompose)
+	d.Set("environment", stack.Environment)
+	d.Set("external_id", stack.ExternalId)
+	d.Set("state", stack.State)
+	d.Set("health_state", stack.HealthState)
+	d.Set("services", stackServices)
+
+	return nil
+}
+
+// findStackByName returns the only stack with the given name in the
+// environment the client is scoped to.
+func findStackByName(client *rancher.RancherClient, name string) (*rancher.Environment, error) {
+	opts := rancher.NewListOpts()
+	opts.Filters["name"] = name
+
+	stacks, err := client.Environment.List(opts)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list stacks: %s", err)
+	}
+
+	var found []rancher.Environment
+	for _, stack := range stacks.Data {
+		if stack.Name == name && !isRemovedState(stack.State) {
+			found = append(found, stack)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("Stack with name %q not found", name)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("Found %d stacks with name %q, expected exactly one", len(found), name)
+	}
+}
@@ -0,0 +1,41 @@
+package rancher
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRancherStackDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRancherStackDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rancher_stack.foo", "description", "Terraform acc test group - data source"),
+					resource.TestCheckResourceAttr("data.rancher_stack.foo", "docker_compose", "web: { image: nginx }"),
+					resource.TestCheckResourceAttr("data.rancher_stack.foo", "services.#", "1"),
+					resource.TestCheckResourceAttr("data.rancher_stack.foo", "services.0.name", "web"),
+					resource.TestCheckResourceAttr("data.rancher_stack.foo", "services.0.kind", "service"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRancherStackDataSourceConfig = `
+resource "rancher_stack" "foo" {
+	name = "foo-data-source"
+	description = "Terraform acc test group - data source"
+	environment_id = "1a5"
+	docker_compose = "web: { image: nginx }"
+	rancher_compose = "web: { scale: 1 }"
+}
+
+data "rancher_stack" "foo" {
+	name = "${rancher_stack.foo.name}"
+	environment_id = "${rancher_stack.foo.environment_id}"
+}
+`
@@ -0,0 +1,135 @@
+package rancher
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rancher "github.com/rancher/go-rancher/client"
+)
+
+func dataSourceRancherStoragePools() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRancherStoragePoolsRead,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"driver_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"storage_pools": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kind": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"driver_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"volume_access_mode": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host_ids": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRancherStoragePoolsRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := environmentClient(d, meta)
+	if err != nil {
+		return err
+	}
+	envID := d.Get("environment_id").(string)
+
+	opts := rancher.NewListOpts()
+	driverName := d.Get("driver_name").(string)
+	if driverName != "" {
+		opts.Filters["driverName"] = driverName
+	}
+
+	log.Printf("[INFO] Listing Storage Pools of Environment: %s", envID)
+
+	pools, err := client.StoragePool.List(opts)
+	if err != nil {
+		return fmt.Errorf("Failed to list storage pools: %s", err)
+	}
+
+	var result []map[string]interface{}
+	for _, pool := range pools.Data {
+		if isRemovedState(pool.State) || (driverName != "" && pool.DriverName != driverName) {
+			continue
+		}
+
+		hostIDs, err := storagePoolHostIDs(client, &pool)
+		if err != nil {
+			return err
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":                 pool.Id,
+			"name":               pool.Name,
+			"kind":               pool.Kind,
+			"driver_name":        pool.DriverName,
+			"volume_access_mode": pool.VolumeAccessMode,
+			"state":              pool.State,
+			"host_ids":           hostIDs,
+		})
+	}
+
+	log.Printf("[INFO] Found %d Storage Pools", len(result))
+
+	d.SetId(envID)
+	d.Set("storage_pools", result)
+
+	return nil
+}
+
+// storagePoolHostIDs returns the IDs of the hosts a storage pool is available on
+func storagePoolHostIDs(client *rancher.RancherClient, pool *rancher.StoragePool) ([]string, error) {
+	hostIDs := []string{}
+	if _, ok := pool.Links["hosts"]; !ok {
+		return hostIDs, nil
+	}
+
+	var hosts rancher.HostCollection
+	if err := client.GetLink(pool.Resource, "hosts", &hosts); err != nil {
+		return nil, fmt.Errorf("Failed to get hosts of storage pool %s: %s", pool.Id, err)
+	}
+
+	for _, host := range hosts.Data {
+		if !isRemovedState(host.State) {
+			hostIDs = append(hostIDs, host.Id)
+		}
+	}
+
+	return hostIDs, nil
+}
@@ -0,0 +1,30 @@
+package rancher
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRancherStoragePoolsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRancherStoragePoolsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rancher_storage_pools.foo", "id", "1a5"),
+					resource.TestMatchResourceAttr("data.rancher_storage_pools.foo", "storage_pools.#", regexp.MustCompile("^[0-9]+$")),
+				),
+			},
+		},
+	})
+}
+
+const testAccRancherStoragePoolsDataSourceConfig = `
+data "rancher_storage_pools" "foo" {
+	environment_id = "1a5"
+}
+`
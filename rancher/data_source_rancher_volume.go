@@ -0,0 +1,131 @@
+package rancher
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rancher "github.com/rancher/go-rancher/client"
+)
+
+func dataSourceRancherVolume() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRancherVolumeRead,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"driver": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"driver_opts": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"access_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_host_path": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"storage_pool_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"host_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceRancherVolumeRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := environmentClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Looking up Volume: %s", name)
+
+	opts := rancher.NewListOpts()
+	opts.Filters["name"] = name
+
+	volumes, err := client.Volume.List(opts)
+	if err != nil {
+		return fmt.Errorf("Failed to list volumes: %s", err)
+	}
+
+	var found []rancher.Volume
+	for _, volume := range volumes.Data {
+		if volume.Name == name && !isRemovedState(volume.State) {
+			found = append(found, volume)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return fmt.Errorf("Volume with name %q not found", name)
+	case 1:
+	default:
+		return fmt.Errorf("Found %d volumes with name %q, expected exactly one", len(found), name)
+	}
+
+	volume := found[0]
+	log.Printf("[INFO] Volume ID: %s", volume.Id)
+
+	poolIDs := []string{}
+	hostIDs := []string{}
+	if _, ok := volume.Links["storagePools"]; ok {
+		var pools rancher.StoragePoolCollection
+		if err := client.GetLink(volume.Resource, "storagePools", &pools); err != nil {
+			return fmt.Errorf("Failed to get storage pools of volume %s: %s", volume.Id, err)
+		}
+
+		for _, pool := range pools.Data {
+			if isRemovedState(pool.State) {
+				continue
+			}
+			poolIDs = append(poolIDs, pool.Id)
+
+			poolHostIDs, err := storagePoolHostIDs(client, &pool)
+			if err != nil {
+				return err
+			}
+			hostIDs = append(hostIDs, poolHostIDs...)
+		}
+	}
+
+	d.SetId(volume.Id)
+	d.Set("description", volume.Description)
+	d.Set("driver", volume.Driver)
+	d.Set("driver_opts", volume.DriverOpts)
+	d.Set("access_mode", volume.AccessMode)
+	d.Set("is_host_path", volume.IsHostPath)
+	d.Set("state", volume.State)
+	d.Set("storage_pool_ids", poolIDs)
+	d.Set("host_ids", hostIDs)
+
+	return nil
+}
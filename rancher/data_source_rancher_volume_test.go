@@ -0,0 +1,28 @@
+package rancher
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRancherVolumeDataSource_missing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccRancherVolumeDataSourceMissingConfig,
+				ExpectError: regexp.MustCompile("not found"),
+			},
+		},
+	})
+}
+
+const testAccRancherVolumeDataSourceMissingConfig = `
+data "rancher_volume" "missing" {
+	environment_id = "1a5"
+	name = "terraform-acc-test-missing"
+}
+`
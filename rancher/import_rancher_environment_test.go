@@ -22,7 +22,7 @@ func TestAccRancherEnvironment_importBasic(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{},
+				ImportStateVerifyIgnore: []string{"force_delete"},
 			},
 		},
 	})
@@ -0,0 +1,149 @@
+package rancher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxLoggedBody is the maximum number of bytes of a body that are logged
+const maxLoggedBody = 4096
+
+const redacted = "[REDACTED]"
+
+// redactedFields are the fields of the API objects holding secrets, like the
+// keys of certificates and the registration tokens of hosts.
+var redactedFields = map[string]bool{
+	"secretValue":     true,
+	"secretKey":       true,
+	"key":             true,
+	"token":           true,
+	"command":         true,
+	"registrationUrl": true,
+}
+
+// logTransport logs the requests to the Rancher API and their responses,
+// with the credentials and secrets in them redacted.
+type logTransport struct {
+	next http.RoundTripper
+}
+
+func newLogTransport(next http.RoundTripper) *logTransport {
+	return &logTransport{next: next}
+}
+
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	reqURL := redactURL(req.URL)
+	log.Printf("[DEBUG] Rancher API request: %s %s\n%s%s",
+		req.Method, reqURL, formatHeaders(req.Header), formatBody(body))
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	if err != nil {
+		log.Printf("[DEBUG] Rancher API request failed: %s %s (%s): %s", req.Method, reqURL, latency, err)
+		return resp, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	log.Printf("[DEBUG] Rancher API response: %s %s %s (%s)\n%s",
+		req.Method, reqURL, resp.Status, latency, formatBody(respBody))
+
+	return resp, nil
+}
+
+// redactURL returns the URL without the password of its user info
+func redactURL(u *url.URL) string {
+	if u.User == nil {
+		return u.String()
+	}
+
+	redactedURL := *u
+	redactedURL.User = url.UserPassword(u.User.Username(), redacted)
+	return redactedURL.String()
+}
+
+func formatHeaders(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	for _, name := range names {
+		value := strings.Join(headers[name], ", ")
+		if name == "Authorization" {
+			value = redacted
+		}
+		fmt.Fprintf(&buf, "%s: %s\n", name, value)
+	}
+
+	return buf.String()
+}
+
+// formatBody returns the body with its secret fields redacted, truncated to
+// maxLoggedBody bytes.
+func formatBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err == nil {
+		if redactedBody, err := json.Marshal(redactFields(data)); err == nil {
+			body = redactedBody
+		}
+	}
+
+	if len(body) > maxLoggedBody {
+		return fmt.Sprintf("%s... (%d bytes truncated)", body[:maxLoggedBody], len(body)-maxLoggedBody)
+	}
+
+	return string(body)
+}
+
+// redactFields replaces the values of the secret fields in decoded JSON
+func redactFields(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for field, value := range v {
+			if redactedFields[field] && value != nil && value != "" {
+				v[field] = redacted
+			} else {
+				v[field] = redactFields(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactFields(value)
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,60 @@
+package rancher
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), "registry-secret") {
+			t.Errorf("Bad request body: %s", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":[{"name":"web","key":"cert-key"},{"token":"reg-token","command":"sudo docker run rancher/agent http://rancher/v1/scripts/reg-token","registrationUrl":"http://rancher/v1/scripts/reg-token"}],"secretKey":"api-secret"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	req, _ := http.NewRequest("POST", server.URL+"/v1/registrycredentials", strings.NewReader(`{"publicValue":"user","secretValue":"registry-secret"}`))
+	req.SetBasicAuth("access", "secret")
+
+	resp, err := newLogTransport(http.DefaultTransport).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "api-secret") {
+		t.Fatalf("Bad response body: %s", body)
+	}
+
+	logs := buf.String()
+	for _, secret := range []string{"registry-secret", "cert-key", "api-secret", "reg-token", "Basic "} {
+		if strings.Contains(logs, secret) {
+			t.Fatalf("Logs contain %q:\n%s", secret, logs)
+		}
+	}
+	for _, expected := range []string{"POST " + server.URL + "/v1/registrycredentials", "201 Created", `"publicValue":"user"`, `"name":"web"`} {
+		if !strings.Contains(logs, expected) {
+			t.Fatalf("Logs don't contain %q:\n%s", expected, logs)
+		}
+	}
+}
+
+func TestFormatBody_truncated(t *testing.T) {
+	body := formatBody(bytes.Repeat([]byte("a"), maxLoggedBody+10))
+	if !strings.HasSuffix(body, "... (10 bytes truncated)") {
+		t.Fatalf("Bad truncated body: %s", body[maxLoggedBody:])
+	}
+}
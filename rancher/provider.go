@@ -1,6 +1,8 @@
 package rancher
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	// "github.com/rancher/go-rancher/client"
@@ -12,22 +14,107 @@ func Provider() terraform.ResourceProvider {
 		Schema: map[string]*schema.Schema{
 			"api_url": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("RANCHER_URL", nil),
 				Description: descriptions["api_url"],
 			},
 			"access_key": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("RANCHER_ACCESS_KEY", nil),
 				Description: descriptions["access_key"],
 			},
 			"secret_key": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("RANCHER_SECRET_KEY", nil),
 				Description: descriptions["secret_key"],
 			},
+			"config": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_CLIENT_CONFIG", defaultCLIConfigPath),
+				Description: descriptions["config"],
+			},
+			"timeout": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_TIMEOUT", 10),
+				Description: descriptions["timeout"],
+			},
+			"max_retries": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_MAX_RETRIES", 5),
+				Description: descriptions["max_retries"],
+			},
+			"max_concurrent_requests": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_MAX_CONCURRENT_REQUESTS", 0),
+				Description: descriptions["max_concurrent_requests"],
+			},
+			"requests_per_second": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_REQUESTS_PER_SECOND", 0),
+				Description: descriptions["requests_per_second"],
+			},
+			"ca_certs": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_CA_CERTS", ""),
+				Description: descriptions["ca_certs"],
+			},
+			"insecure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_INSECURE", false),
+				Description: descriptions["insecure"],
+			},
+			"client_cert": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_CLIENT_CERT", ""),
+				Description: descriptions["client_cert"],
+			},
+			"client_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_CLIENT_KEY", ""),
+				Description: descriptions["client_key"],
+			},
+			"skip_credentials_validation": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["skip_credentials_validation"],
+			},
+			"environment_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_ENVIRONMENT_ID", ""),
+				Description: descriptions["environment_id"],
+			},
+			"environment_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("RANCHER_ENVIRONMENT_NAME", ""),
+				Description: descriptions["environment_name"],
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"rancher_catalog_template": dataSourceRancherCatalogTemplate(),
+			"rancher_certificate":      dataSourceRancherCertificate(),
+			"rancher_environment":      dataSourceRancherEnvironment(),
+			"rancher_hosts":            dataSourceRancherHosts(),
+			"rancher_registry":         dataSourceRancherRegistry(),
+			"rancher_service":          dataSourceRancherService(),
+			"rancher_setting":          dataSourceRancherSetting(),
+			"rancher_stack":            dataSourceRancherStack(),
+			"rancher_storage_pools":    dataSourceRancherStoragePools(),
+			"rancher_volume":           dataSourceRancherVolume(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -51,17 +138,73 @@ func init() {
 		"secret_key": "API secret used to authenticate with the rancher server",
 
 		"api_url": "The URL to the rancher API",
+
+		"config": "Path to the rancher CLI configuration file, used for the settings that aren't given",
+
+		"timeout": "Timeout in seconds of the requests to the rancher API",
+
+		"max_retries": "Maximum number of times a request failing with a transient error is retried",
+
+		"max_concurrent_requests": "Maximum number of concurrent requests to the rancher API, 0 for no limit",
+
+		"requests_per_second": "Maximum number of requests per second to the rancher API, 0 for no limit",
+
+		"ca_certs": "PEM encoded CA certificates to trust when connecting to the rancher API",
+
+		"insecure": "Whether to skip the verification of the rancher API TLS certificate",
+
+		"client_cert": "PEM encoded client certificate to authenticate with the rancher server",
+
+		"client_key": "PEM encoded private key of client_cert",
+
+		"skip_credentials_validation": "Skip the validation of the settings and credentials when the provider is configured",
+
+		"environment_id": "ID of the environment used by resources that don't set their own",
+
+		"environment_name": "Name of the environment used by resources that don't set their own",
 	}
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := &Config{
-		APIURL:    d.Get("api_url").(string) + "/v1",
-		AccessKey: d.Get("access_key").(string),
-		SecretKey: d.Get("secret_key").(string),
+		APIURL:     d.Get("api_url").(string),
+		AccessKey:  d.Get("access_key").(string),
+		SecretKey:  d.Get("secret_key").(string),
+		Timeout:    time.Duration(d.Get("timeout").(int)) * time.Second,
+		MaxRetries: d.Get("max_retries").(int),
+		CACerts:    d.Get("ca_certs").(string),
+		Insecure:   d.Get("insecure").(bool),
+		ClientCert: d.Get("client_cert").(string),
+		ClientKey:  d.Get("client_key").(string),
+
+		MaxConcurrentRequests:     d.Get("max_concurrent_requests").(int),
+		RequestsPerSecond:         d.Get("requests_per_second").(int),
+		SkipCredentialsValidation: d.Get("skip_credentials_validation").(bool),
+
+		EnvironmentID:   d.Get("environment_id").(string),
+		EnvironmentName: d.Get("environment_name").(string),
+	}
+
+	cli, err := loadCLIConfig(d.Get("config").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.APIURL == "" {
+		config.APIURL = cli.URL
+	}
+	if config.AccessKey == "" {
+		config.AccessKey = cli.AccessKey
+	}
+	if config.SecretKey == "" {
+		config.SecretKey = cli.SecretKey
+	}
+	// The CLI default environment only makes sense with the CLI credentials
+	if config.EnvironmentID == "" && config.EnvironmentName == "" && config.AccessKey == cli.AccessKey {
+		config.EnvironmentID = cli.Environment
 	}
 
-	err := config.CreateClient()
+	err = config.CreateClient()
 
 	return config, err
 }
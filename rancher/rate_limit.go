@@ -0,0 +1,90 @@
+package rancher
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimitTransport limits the number of requests to the Rancher API that
+// are sent concurrently and per second, to avoid overloading small servers.
+//
+// Requests wait for their turn until they are canceled or their client times
+// out. Since the timeout of each attempt is applied after this transport, the
+// time waiting doesn't count against it.
+//
+// A request takes a concurrency slot until its response headers are received.
+// Holding it until the body is closed would deadlock the clients, which keep
+// a response open while making the next request when loading their schemas.
+type rateLimitTransport struct {
+	next http.RoundTripper
+
+	// slots is nil when the concurrency is not limited
+	slots chan struct{}
+
+	// interval is zero when the rate is not limited
+	interval time.Duration
+	mu       sync.Mutex
+	nextAt   time.Time
+}
+
+func newRateLimitTransport(next http.RoundTripper, maxConcurrent int, perSecond int) *rateLimitTransport {
+	t := &rateLimitTransport{next: next}
+
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	if perSecond > 0 {
+		t.interval = time.Second / time.Duration(perSecond)
+	}
+
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+			defer func() { <-t.slots }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := t.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// wait blocks until the next request is allowed by the rate limit, or the
+// request is canceled.
+func (t *rateLimitTransport) wait(ctx context.Context) error {
+	if t.interval == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	at := t.nextAt
+	if at.Before(now) {
+		at = now
+	}
+	t.nextAt = at.Add(t.interval)
+	t.mu.Unlock()
+
+	timer := time.NewTimer(at.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,96 @@
+package rancher
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRateLimitTransport_concurrency(t *testing.T) {
+	var active, maxActive int32
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&active, 1)
+		for {
+			max := atomic.LoadInt32(&maxActive)
+			if n <= max || atomic.CompareAndSwapInt32(&maxActive, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	transport := newRateLimitTransport(next, 2, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", "http://rancher.example.com/v1", nil)
+			transport.RoundTrip(req)
+		}()
+	}
+	wg.Wait()
+
+	if maxActive != 2 {
+		t.Fatalf("Bad concurrent requests: %d should be: %d", maxActive, 2)
+	}
+}
+
+func TestRateLimitTransport_rate(t *testing.T) {
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	transport := newRateLimitTransport(next, 0, 50)
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		req, _ := http.NewRequest("GET", "http://rancher.example.com/v1", nil)
+		transport.RoundTrip(req)
+	}
+
+	// The first request is sent right away, and each of the rest 20ms later
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("Requests were sent too fast: %s", elapsed)
+	}
+}
+
+func TestRateLimitTransport_canceled(t *testing.T) {
+	release := make(chan struct{})
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		<-release
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	transport := newRateLimitTransport(next, 1, 0)
+	defer close(release)
+
+	// Take the only slot
+	go func() {
+		req, _ := http.NewRequest("GET", "http://rancher.example.com/v1", nil)
+		transport.RoundTrip(req)
+	}()
+	for len(transport.slots) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequest("GET", "http://rancher.example.com/v1", nil)
+	if _, err := transport.RoundTrip(req.WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Fatalf("Bad error waiting for a slot: %v should be: %v", err, context.DeadlineExceeded)
+	}
+}
@@ -31,21 +31,40 @@ func resourceRancherEnvironment() *schema.Resource {
 				Required: true,
 			},
 			"orchestration": &schema.Schema{
-				Type:     schema.TypeString,
-				Default:  "cattle",
-				Optional: true,
+				Type:         schema.TypeString,
+				Default:      "cattle",
+				Optional:     true,
+				ValidateFunc: validateEnvironmentOrchestration,
+				StateFunc: func(v interface{}) string {
+					return normalizeOrchestration(v.(string))
+				},
 			},
 			"description": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"state": &schema.Schema{
+				Type:         schema.TypeString,
+				Default:      "active",
+				Optional:     true,
+				ValidateFunc: validateEnvironmentState,
+			},
+			"force_delete": &schema.Schema{
+				Type:     schema.TypeBool,
+				Default:  false,
+				Optional: true,
+			},
 		},
 	}
 }
 
 func resourceRancherEnvironmentCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Creating Environment: %s", d.Id())
-	client := meta.(*Config)
+	config := meta.(*Config)
+	client, err := config.Client()
+	if err != nil {
+		return err
+	}
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -66,29 +85,54 @@ func resourceRancherEnvironmentCreate(d *schema.ResourceData, meta interface{})
 	d.SetId(newEnv.Id)
 	log.Printf("[INFO] Environment ID: %s", d.Id())
 
+	if d.Get("state").(string) == "inactive" {
+		if err := setEnvironmentState(client, d.Id(), "inactive"); err != nil {
+			return err
+		}
+	}
+
 	return resourceRancherEnvironmentRead(d, meta)
 }
 
 func resourceRancherEnvironmentRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Refreshing Environment: %s", d.Id())
-	client := meta.(*Config)
+	config := meta.(*Config)
+	client, err := config.Client()
+	if err != nil {
+		return err
+	}
 
 	env, err := client.Project.ById(d.Id())
 	if err != nil {
 		return err
 	}
 
+	if env == nil || isRemovedState(env.State) {
+		log.Printf("[WARN] Environment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	log.Printf("[INFO] Environment Name: %s", env.Name)
 
 	d.Set("description", env.Description)
 	d.Set("name", env.Name)
 	d.Set("orchestration", GetActiveOrchestration(env))
 
+	switch env.State {
+	case "active", "inactive":
+		d.Set("state", env.State)
+	}
+
 	return nil
 }
 
 func resourceRancherEnvironmentUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config)
+	config := meta.(*Config)
+	client, err := config.Client()
+	if err != nil {
+		return err
+	}
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -106,33 +150,137 @@ func resourceRancherEnvironmentUpdate(d *schema.ResourceData, meta interface{})
 	if err != nil {
 		return err
 	}
+	if env == nil {
+		return fmt.Errorf("Environment (%s) not found", d.Id())
+	}
 
 	if err := client.Update("project", &env.Resource, data, &newEnv); err != nil {
 		return err
 	}
 
+	// Rancher rebuilds the infrastructure stacks of the new orchestration
+	// asynchronously, so wait for them before reporting the update as done.
+	if d.HasChange("orchestration") {
+		envClient, err := config.EnvironmentClient(d.Id())
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG] Waiting for environment (%s) %s system stacks to be active", d.Id(), orchestration)
+
+		stateConf := &resource.StateChangeConf{
+			Pending:                   []string{"activating"},
+			Target:                    []string{"active"},
+			Refresh:                   EnvironmentSystemStacksStateRefreshFunc(envClient, orchestration),
+			Timeout:                   30 * time.Minute,
+			Delay:                     5 * time.Second,
+			MinTimeout:                3 * time.Second,
+			ContinuousTargetOccurence: 3,
+		}
+
+		_, waitErr := stateConf.WaitForState()
+		if waitErr != nil {
+			return fmt.Errorf(
+				"Error waiting for environment (%s) orchestration to be %s: %s", d.Id(), orchestration, waitErr)
+		}
+	}
+
+	if d.HasChange("state") {
+		if err := setEnvironmentState(client, d.Id(), d.Get("state").(string)); err != nil {
+			return err
+		}
+	}
+
 	return resourceRancherEnvironmentRead(d, meta)
 }
 
 func resourceRancherEnvironmentDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Deleting Environment: %s", d.Id())
 	id := d.Id()
-	client := meta.(*Config)
+	config := meta.(*Config)
+	client, err := config.Client()
+	if err != nil {
+		return err
+	}
 
 	env, err := client.Project.ById(id)
 	if err != nil {
 		return err
 	}
 
-	if err := client.Project.Delete(env); err != nil {
-		return fmt.Errorf("Error deleting Environment: %s", err)
+	if env == nil || isRemovedState(env.State) {
+		log.Printf("[WARN] Environment (%s) already removed", id)
+		d.SetId("")
+		return nil
+	}
+
+	envClient, err := config.EnvironmentClient(id)
+	if err != nil {
+		return err
+	}
+
+	hosts, stacks, err := countEnvironmentResources(envClient)
+	if err != nil {
+		return fmt.Errorf("Error listing resources of Environment (%s): %s", id, err)
+	}
+
+	forceDelete := d.Get("force_delete").(bool)
+	if (hosts > 0 || stacks > 0) && !forceDelete {
+		return fmt.Errorf(
+			"Environment (%s) still has %d host(s) and %d stack(s), set force_delete to delete it anyway", id, hosts, stacks)
+	}
+
+	if !forceDelete {
+		if err := client.Project.Delete(env); err != nil {
+			return fmt.Errorf("Error deleting Environment: %s", err)
+		}
+
+		log.Printf("[DEBUG] Waiting for environment (%s) to be removed", id)
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"active", "inactive", "removed", "removing"},
+			Target:     []string{"removed", "purged"},
+			Refresh:    EnvironmentStateRefreshFunc(client, id),
+			Timeout:    10 * time.Minute,
+			Delay:      1 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		_, waitErr := stateConf.WaitForState()
+		if waitErr != nil {
+			return fmt.Errorf(
+				"Error waiting for environment (%s) to be removed: %s", id, waitErr)
+		}
+
+		d.SetId("")
+		return nil
+	}
+
+	// Step 1: Deactivate
+	if err := setEnvironmentState(client, id, "inactive"); err != nil {
+		return err
+	}
+
+	// Update resource to reflect its state
+	env, err = client.Project.ById(id)
+	if err != nil {
+		return fmt.Errorf("Failed to refresh state of deactivated environment (%s): %s", id, err)
+	}
+	if env == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// Step 2: Remove
+	if _, err := client.Project.ActionRemove(env); err != nil {
+		return fmt.Errorf("Error removing Environment: %s", err)
 	}
 
 	log.Printf("[DEBUG] Waiting for environment (%s) to be removed", id)
 
 	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"active", "removed", "removing"},
-		Target:     []string{"removed"},
+		Pending:    []string{"inactive", "removed", "removing"},
+		Target:     []string{"removed", "purged"},
 		Refresh:    EnvironmentStateRefreshFunc(client, id),
 		Timeout:    10 * time.Minute,
 		Delay:      1 * time.Second,
@@ -145,27 +293,162 @@ func resourceRancherEnvironmentDelete(d *schema.ResourceData, meta interface{})
 			"Error waiting for environment (%s) to be removed: %s", id, waitErr)
 	}
 
+	// Update resource to reflect its state
+	env, err = client.Project.ById(id)
+	if err != nil {
+		return fmt.Errorf("Failed to refresh state of removed environment (%s): %s", id, err)
+	}
+	if env == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// Step 3: Purge
+	if _, err := client.Project.ActionPurge(env); err != nil {
+		return fmt.Errorf("Error purging Environment: %s", err)
+	}
+
+	log.Printf("[DEBUG] Waiting for environment (%s) to be purged", id)
+
+	stateConf = &resource.StateChangeConf{
+		Pending:    []string{"removed", "purging", "purged"},
+		Target:     []string{"purged"},
+		Refresh:    EnvironmentStateRefreshFunc(client, id),
+		Timeout:    10 * time.Minute,
+		Delay:      1 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, waitErr = stateConf.WaitForState()
+	if waitErr != nil {
+		return fmt.Errorf(
+			"Error waiting for environment (%s) to be purged: %s", id, waitErr)
+	}
+
 	d.SetId("")
 	return nil
 }
 
-func setOrchestrationFields(orchestration string, data map[string]interface{}) {
-	orch := strings.ToLower(orchestration)
+// setEnvironmentState activates or deactivates an environment and waits for
+// it to reach the requested state.
+func setEnvironmentState(client *rancher.RancherClient, id string, state string) error {
+	env, err := client.Project.ById(id)
+	if err != nil {
+		return err
+	}
 
-	data["swarm"] = false
-	data["kubernetes"] = false
-	data["mesos"] = false
+	if env == nil {
+		return fmt.Errorf("Environment (%s) not found", id)
+	}
+
+	if env.State == state {
+		return nil
+	}
+
+	var pending []string
+	switch state {
+	case "active":
+		if _, err := client.Project.ActionActivate(env); err != nil {
+			return fmt.Errorf("Error activating Environment: %s", err)
+		}
+		pending = []string{"inactive", "activating"}
+	case "inactive":
+		if _, err := client.Project.ActionDeactivate(env); err != nil {
+			return fmt.Errorf("Error deactivating Environment: %s", err)
+		}
+		pending = []string{"active", "deactivating"}
+	default:
+		return fmt.Errorf("Unknown environment state: %s", state)
+	}
+
+	log.Printf("[DEBUG] Waiting for environment (%s) to be %s", id, state)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     []string{state},
+		Refresh:    EnvironmentStateRefreshFunc(client, id),
+		Timeout:    10 * time.Minute,
+		Delay:      1 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, waitErr := stateConf.WaitForState()
+	if waitErr != nil {
+		return fmt.Errorf(
+			"Error waiting for environment (%s) to be %s: %s", id, state, waitErr)
+	}
+
+	return nil
+}
+
+// countEnvironmentResources returns the number of hosts and stacks that are
+// still present in an environment, not counting the system stacks Rancher
+// creates in every environment.
+func countEnvironmentResources(client *rancher.RancherClient) (hosts int, stacks int, err error) {
+	hostList, err := client.Host.List(rancher.NewListOpts())
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, host := range hostList.Data {
+		if !isRemovedState(host.State) {
+			hosts++
+		}
+	}
+
+	stackList, err := listStacks(client)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, stack := range stackList {
+		if !isRemovedState(stack.State) && !isSystemStack(&stack) {
+			stacks++
+		}
+	}
+
+	return hosts, stacks, nil
+}
+
+func validateEnvironmentState(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "active", "inactive":
+	default:
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of active or inactive, got: %s", k, v))
+	}
+	return
+}
+
+func validateEnvironmentOrchestration(v interface{}, k string) (ws []string, errors []error) {
+	switch strings.ToLower(v.(string)) {
+	case "cattle", "swarm", "mesos", "kubernetes", "k8s":
+	default:
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of cattle, swarm, mesos or kubernetes, got: %s", k, v))
+	}
+	return
+}
 
+// normalizeOrchestration returns the name of an orchestration as it is read
+// back from the environment.
+func normalizeOrchestration(orchestration string) string {
+	orch := strings.ToLower(orchestration)
 	if orch == "k8s" {
 		orch = "kubernetes"
 	}
+	return orch
+}
+
+func setOrchestrationFields(orchestration string, data map[string]interface{}) {
+	data["swarm"] = false
+	data["kubernetes"] = false
+	data["mesos"] = false
 
-	data[orch] = true
+	data[normalizeOrchestration(orchestration)] = true
 }
 
 // EnvironmentStateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
 // a Rancher Environment.
-func EnvironmentStateRefreshFunc(client *Config, environmentID string) resource.StateRefreshFunc {
+func EnvironmentStateRefreshFunc(client *rancher.RancherClient, environmentID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		env, err := client.Project.ById(environmentID)
 
@@ -173,6 +456,77 @@ func EnvironmentStateRefreshFunc(client *Config, environmentID string) resource.
 			return nil, "", err
 		}
 
+		// Purged environments eventually stop being returned by the API
+		if env == nil {
+			return &rancher.Project{}, "purged", nil
+		}
+
 		return env, env.State, nil
 	}
 }
+
+// EnvironmentSystemStacksStateRefreshFunc returns a resource.StateRefreshFunc that is used to
+// watch the system stacks of a Rancher Environment until the ones of the given orchestration
+// are present and active, and the ones of other orchestrations are removed.
+func EnvironmentSystemStacksStateRefreshFunc(client *rancher.RancherClient, orchestration string) resource.StateRefreshFunc {
+	orchestration = normalizeOrchestration(orchestration)
+
+	return func() (interface{}, string, error) {
+		stacks, err := listStacks(client)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Cattle has no system stacks of its own
+		provisioned := orchestration == "cattle"
+		state := "active"
+		for _, stack := range stacks {
+			if !isSystemStack(&stack) || isRemovedState(stack.State) {
+				continue
+			}
+
+			stackOrchestration := systemStackOrchestration(&stack)
+			switch {
+			case stackOrchestration != "" && stackOrchestration != orchestration:
+				// The stacks of the previous orchestration are still being removed
+				state = "activating"
+			case stack.State == "error":
+				return nil, "", fmt.Errorf("System stack %s (%s) is in error state: %s", stack.Name, stack.Id, stack.TransitioningMessage)
+			case stack.State != "active":
+				state = "activating"
+			case stackOrchestration == orchestration:
+				provisioned = true
+			}
+		}
+
+		if !provisioned {
+			state = "activating"
+		}
+
+		return stacks, state, nil
+	}
+}
+
+// orchestrationStackNames are the names in the external IDs of the system
+// stacks that run each orchestration, like system://kubernetes in Rancher 1.1
+// and catalog://library:infra*k8s:1 in Rancher 1.2.
+var orchestrationStackNames = map[string][]string{
+	"kubernetes": {"kubernetes", "k8s"},
+	"swarm":      {"swarm"},
+	"mesos":      {"mesos"},
+}
+
+// systemStackOrchestration returns the orchestration a system stack runs, or
+// an empty string for the stacks that all environments have.
+func systemStackOrchestration(stack *apiStack) string {
+	externalID := strings.ToLower(stack.ExternalId)
+	for orchestration, names := range orchestrationStackNames {
+		for _, name := range names {
+			if strings.Contains(externalID, name) {
+				return orchestration
+			}
+		}
+	}
+
+	return ""
+}
@@ -2,6 +2,8 @@ package rancher
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -31,6 +33,13 @@ func TestAccRancherEnvironment(t *testing.T) {
 					testAccCheckRancherEnvironmentAttributes(&environment, "foo2", "Terraform acc test group - updated", "swarm"),
 				),
 			},
+			resource.TestStep{
+				Config: testAccRancherEnvironmentInactiveConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRancherEnvironmentExists("rancher_environment.foo", &environment),
+					resource.TestCheckResourceAttr("rancher_environment.foo", "state", "inactive"),
+				),
+			},
 		},
 	})
 }
@@ -120,3 +129,93 @@ resource "rancher_environment" "foo" {
 	orchestration = "swarm"
 }
 `
+
+const testAccRancherEnvironmentInactiveConfig = `
+resource "rancher_environment" "foo" {
+	name = "foo2"
+	description = "Terraform acc test group - updated"
+	orchestration = "swarm"
+	state = "inactive"
+}
+`
+
+func TestSystemStackOrchestration(t *testing.T) {
+	cases := map[string]string{
+		"system://kubernetes":                "kubernetes",
+		"catalog://library:infra*k8s:16":     "kubernetes",
+		"catalog://library:infra*swarm:3":    "swarm",
+		"catalog://library:infra*mesos:2":    "mesos",
+		"catalog://library:infra*ipsec:4":    "",
+		"system-catalog://library:scheduler": "",
+	}
+
+	for externalID, expected := range cases {
+		stack := &apiStack{}
+		stack.ExternalId = externalID
+		if orchestration := systemStackOrchestration(stack); orchestration != expected {
+			t.Fatalf("Bad orchestration of %s: %q should be: %q", externalID, orchestration, expected)
+		}
+	}
+}
+
+func testStacksClient(t *testing.T, stacks string) (*rancher.RancherClient, *httptest.Server) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/schemas":
+			w.Header().Set("X-API-Schemas", "http://"+r.Host+"/v1/schemas")
+			w.Write([]byte(`{"data":[{"id":"environment","links":{"collection":"http://` + r.Host + `/v1/environments"},"collectionMethods":["GET"]}]}`))
+		case "/v1/environments":
+			w.Write([]byte(`{"data":` + stacks + `}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	client, err := rancher.NewRancherClient(&rancher.ClientOpts{Url: server.URL + "/v1/schemas"})
+	if err != nil {
+		server.Close()
+		t.Fatalf("err: %s", err)
+	}
+
+	return client, server
+}
+
+func TestEnvironmentSystemStacksStateRefreshFunc(t *testing.T) {
+	const (
+		healthcheck = `{"id":"1e1","externalId":"catalog://library:infra*healthcheck:2","state":"active"}`
+		k8s         = `{"id":"1e2","externalId":"catalog://library:infra*k8s:16","state":"active"}`
+		k8sPending  = `{"id":"1e2","externalId":"catalog://library:infra*k8s:16","state":"activating"}`
+		k8sLegacy   = `{"id":"1e3","externalId":"system://kubernetes","state":"active"}`
+		swarm       = `{"id":"1e4","externalId":"catalog://library:infra*swarm:3","state":"active"}`
+		swarmGone   = `{"id":"1e4","externalId":"catalog://library:infra*swarm:3","state":"removed"}`
+		userStack   = `{"id":"1e5","externalId":"","state":"activating"}`
+	)
+
+	cases := []struct {
+		Orchestration string
+		Stacks        string
+		State         string
+	}{
+		{"kubernetes", "[" + healthcheck + "," + k8s + "," + userStack + "]", "active"},
+		{"k8s", "[" + healthcheck + "," + k8s + "," + swarmGone + "]", "active"},
+		{"kubernetes", "[" + k8sLegacy + "]", "active"},
+		{"kubernetes", "[" + healthcheck + "]", "activating"},
+		{"kubernetes", "[" + healthcheck + "," + k8sPending + "]", "activating"},
+		{"kubernetes", "[" + k8s + "," + swarm + "]", "activating"},
+		{"cattle", "[" + healthcheck + "," + swarm + "]", "activating"},
+		{"cattle", "[" + healthcheck + "," + swarmGone + "]", "active"},
+	}
+
+	for i, tc := range cases {
+		client, server := testStacksClient(t, tc.Stacks)
+		_, state, err := EnvironmentSystemStacksStateRefreshFunc(client, tc.Orchestration)()
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if state != tc.State {
+			t.Fatalf("%d: Bad state: %s should be: %s", i, state, tc.State)
+		}
+	}
+}
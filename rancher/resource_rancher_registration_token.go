@@ -3,6 +3,8 @@ package rancher
 import (
 	"fmt"
 	"log"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -14,6 +16,7 @@ func resourceRancherRegistrationToken() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceRancherRegistrationTokenCreate,
 		Read:   resourceRancherRegistrationTokenRead,
+		Update: resourceRancherRegistrationTokenUpdate,
 		Delete: resourceRancherRegistrationTokenDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -26,7 +29,7 @@ func resourceRancherRegistrationToken() *schema.Resource {
 			},
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 			},
 			"description": &schema.Schema{
@@ -36,7 +39,14 @@ func resourceRancherRegistrationToken() *schema.Resource {
 			},
 			"environment_id": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"reuse_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 				ForceNew: true,
 			},
 			"token": &schema.Schema{
@@ -47,17 +57,53 @@ func resourceRancherRegistrationToken() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"command": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"image": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"host_labels": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"command_with_labels": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func resourceRancherRegistrationTokenCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Creating RegistrationToken: %s", d.Id())
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+
+	// The name can only be left out when reusing the token of the environment
+	if d.Get("name").(string) == "" && !d.Get("reuse_existing").(bool) {
+		return fmt.Errorf("name must be set unless reuse_existing is true")
+	}
+
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
 
+	if d.Get("reuse_existing").(bool) {
+		regT, err := findActiveRegistrationToken(client)
+		if err != nil {
+			return err
+		}
+
+		if regT != nil {
+			d.SetId(regT.Id)
+			log.Printf("[INFO] Reusing RegistrationToken ID: %s", d.Id())
+
+			return resourceRancherRegistrationTokenRead(d, meta)
+		}
+	}
+
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 
@@ -79,7 +125,7 @@ func resourceRancherRegistrationTokenCreate(d *schema.ResourceData, meta interfa
 
 func resourceRancherRegistrationTokenRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Refreshing RegistrationToken: %s", d.Id())
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
@@ -89,20 +135,45 @@ func resourceRancherRegistrationTokenRead(d *schema.ResourceData, meta interface
 		return err
 	}
 
+	if regT == nil || isRemovedState(regT.State) {
+		log.Printf("[WARN] RegistrationToken (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	log.Printf("[INFO] RegistrationToken Name: %s", regT.Name)
 
-	d.Set("description", regT.Description)
-	d.Set("name", regT.Name)
+	// A reused token keeps its own name and description, the configured
+	// ones are only used when a new token has to be created.
+	if !d.Get("reuse_existing").(bool) {
+		d.Set("description", regT.Description)
+		d.Set("name", regT.Name)
+	}
 	d.Set("token", regT.Token)
 	d.Set("registration_url", regT.RegistrationUrl)
+	d.Set("command", regT.Command)
+	d.Set("image", regT.Image)
+	d.Set("command_with_labels", addHostLabelsToCommand(regT.Command, d.Get("host_labels").(map[string]interface{})))
 
 	return nil
 }
 
+func resourceRancherRegistrationTokenUpdate(d *schema.ResourceData, meta interface{}) error {
+	// Only host_labels can change in place, and it only affects computed attributes
+	return resourceRancherRegistrationTokenRead(d, meta)
+}
+
 func resourceRancherRegistrationTokenDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Deleting RegistrationToken: %s", d.Id())
 	id := d.Id()
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+
+	if d.Get("reuse_existing").(bool) {
+		log.Printf("[INFO] Leaving reused RegistrationToken (%s) in place", id)
+		d.SetId("")
+		return nil
+	}
+
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
@@ -112,6 +183,12 @@ func resourceRancherRegistrationTokenDelete(d *schema.ResourceData, meta interfa
 		return err
 	}
 
+	if regT == nil || isRemovedState(regT.State) {
+		log.Printf("[WARN] RegistrationToken (%s) already removed", id)
+		d.SetId("")
+		return nil
+	}
+
 	// Step 1: Deactivate
 	if _, err := client.RegistrationToken.ActionDeactivate(regT); err != nil {
 		return fmt.Errorf("Error deactivating RegistrationToken: %s", err)
@@ -139,6 +216,10 @@ func resourceRancherRegistrationTokenDelete(d *schema.ResourceData, meta interfa
 	if err != nil {
 		return fmt.Errorf("Failed to refresh state of deactivated registration token (%s): %s", id, err)
 	}
+	if regT == nil {
+		d.SetId("")
+		return nil
+	}
 
 	// Step 2: Remove
 	if _, err := client.RegistrationToken.ActionRemove(regT); err != nil {
@@ -176,6 +257,50 @@ func RegistrationTokenStateRefreshFunc(client *rancher.RancherClient, regTID str
 			return nil, "", err
 		}
 
+		if regT == nil {
+			return &rancher.RegistrationToken{}, "removed", nil
+		}
+
 		return regT, regT.State, nil
 	}
 }
+
+// findActiveRegistrationToken returns the first active registration token of
+// the environment the client is scoped to, or nil if there is none.
+func findActiveRegistrationToken(client *rancher.RancherClient) (*rancher.RegistrationToken, error) {
+	tokens, err := client.RegistrationToken.List(rancher.NewListOpts())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list registration tokens: %s", err)
+	}
+
+	for _, regT := range tokens.Data {
+		if regT.State == "active" {
+			return &regT, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// addHostLabelsToCommand returns the host registration command with the given
+// labels passed to the agent through CATTLE_HOST_LABELS.
+func addHostLabelsToCommand(command string, labels map[string]interface{}) string {
+	if command == "" || len(labels) == 0 {
+		return command
+	}
+
+	values := url.Values{}
+	for k, v := range labels {
+		// Maps have no element type in this SDK, so values may not be strings
+		values.Set(k, fmt.Sprint(v))
+	}
+	env := fmt.Sprintf("-e CATTLE_HOST_LABELS='%s'", values.Encode())
+
+	i := strings.Index(command, "docker run ")
+	if i < 0 {
+		return command
+	}
+	i += len("docker run ")
+
+	return command[:i] + env + " " + command[i:]
+}
@@ -2,6 +2,7 @@ package rancher
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -22,12 +23,66 @@ func TestAccRancherRegistrationToken(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRancherRegistrationTokenExists("rancher_registration_token.foo", &registrationToken),
 					testAccCheckRancherRegistrationTokenAttributes(&registrationToken, "foo", "Terraform acc test group"),
+					resource.TestMatchResourceAttr("rancher_registration_token.foo", "command", regexp.MustCompile("docker run")),
+					resource.TestMatchResourceAttr("rancher_registration_token.foo", "image", regexp.MustCompile("rancher/agent")),
 				),
 			},
 		},
 	})
 }
 
+func TestAccRancherRegistrationToken_reuseExisting(t *testing.T) {
+	var first, second rancher.RegistrationToken
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRancherRegistrationTokenReuseConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRancherRegistrationTokenExists("rancher_registration_token.first", &first),
+					testAccCheckRancherRegistrationTokenExists("rancher_registration_token.second", &second),
+					func(s *terraform.State) error {
+						if first.Id != second.Id {
+							return fmt.Errorf("Bad token: %s should be: %s", second.Id, first.Id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAddHostLabelsToCommand(t *testing.T) {
+	command := "sudo docker run -d --privileged rancher/agent:v1.0.2 http://rancher/v1/scripts/TOKEN"
+
+	cases := []struct {
+		Labels   map[string]interface{}
+		Expected string
+	}{
+		{
+			Labels:   map[string]interface{}{},
+			Expected: command,
+		},
+		{
+			Labels:   map[string]interface{}{"role": "db", "az": "a"},
+			Expected: "sudo docker run -e CATTLE_HOST_LABELS='az=a&role=db' -d --privileged rancher/agent:v1.0.2 http://rancher/v1/scripts/TOKEN",
+		},
+		{
+			Labels:   map[string]interface{}{"ssd": true, "slot": 2},
+			Expected: "sudo docker run -e CATTLE_HOST_LABELS='slot=2&ssd=true' -d --privileged rancher/agent:v1.0.2 http://rancher/v1/scripts/TOKEN",
+		},
+	}
+
+	for _, tc := range cases {
+		if got := addHostLabelsToCommand(command, tc.Labels); got != tc.Expected {
+			t.Fatalf("Bad command: %s should be: %s", got, tc.Expected)
+		}
+	}
+}
+
 func testAccCheckRancherRegistrationTokenExists(n string, regT *rancher.RegistrationToken) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -91,7 +146,6 @@ func testAccCheckRancherRegistrationTokenDestroy(s *terraform.State) error {
 		return nil
 	}
 	return nil
-	return nil
 }
 
 const testAccRancherRegistrationTokenConfig = `
@@ -101,3 +155,27 @@ resource "rancher_registration_token" "foo" {
 	environment_id = "1a5"
 }
 `
+
+const testAccRancherRegistrationTokenReuseConfig = `
+resource "rancher_registration_token" "first" {
+	environment_id = "1a5"
+	reuse_existing = true
+}
+
+resource "rancher_registration_token" "second" {
+	environment_id = "1a5"
+	reuse_existing = true
+	depends_on = ["rancher_registration_token.first"]
+}
+`
+
+func TestResourceRancherRegistrationTokenCreate_missingName(t *testing.T) {
+	d := resourceRancherRegistrationToken().TestResourceData()
+	d.Set("reuse_existing", false)
+
+	// The name is checked before any request is made, so no provider is needed
+	err := resourceRancherRegistrationTokenCreate(d, nil)
+	if err == nil || err.Error() != "name must be set unless reuse_existing is true" {
+		t.Fatalf("Expected a missing name error, got: %v", err)
+	}
+}
@@ -40,7 +40,8 @@ func resourceRancherRegistry() *schema.Resource {
 			},
 			"environment_id": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 		},
@@ -49,7 +50,7 @@ func resourceRancherRegistry() *schema.Resource {
 
 func resourceRancherRegistryCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Creating Registry: %s", d.Id())
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
@@ -76,7 +77,7 @@ func resourceRancherRegistryCreate(d *schema.ResourceData, meta interface{}) err
 
 func resourceRancherRegistryRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Refreshing Registry: %s", d.Id())
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
@@ -86,6 +87,12 @@ func resourceRancherRegistryRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
+	if env == nil || isRemovedState(env.State) {
+		log.Printf("[WARN] Registry (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	log.Printf("[INFO] Registry Name: %s", env.Name)
 
 	d.Set("description", env.Description)
@@ -96,7 +103,8 @@ func resourceRancherRegistryRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceRancherRegistryUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+	log.Printf("[INFO] Updating Registry: %s", d.Id())
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
@@ -105,13 +113,39 @@ func resourceRancherRegistryUpdate(d *schema.ResourceData, meta interface{}) err
 	if err != nil {
 		return err
 	}
+	if registry == nil {
+		return fmt.Errorf("Registry (%s) not found", d.Id())
+	}
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 
-	registry.Name = name
-	registry.Description = description
-	client.Registry.Update(registry, &registry)
+	data := map[string]interface{}{
+		"name":        &name,
+		"description": &description,
+	}
+
+	var newRegistry rancher.Registry
+	if err := client.Update("registry", &registry.Resource, data, &newRegistry); err != nil {
+		return fmt.Errorf("Error updating Registry: %s", err)
+	}
+
+	log.Printf("[DEBUG] Waiting for registry (%s) to be updated", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"active", "updating-active"},
+		Target:     []string{"active"},
+		Refresh:    RegistryStateRefreshFunc(client, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      1 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, waitErr := stateConf.WaitForState()
+	if waitErr != nil {
+		return fmt.Errorf(
+			"Error waiting for registry (%s) to be updated: %s", d.Id(), waitErr)
+	}
 
 	return resourceRancherRegistryRead(d, meta)
 }
@@ -119,7 +153,7 @@ func resourceRancherRegistryUpdate(d *schema.ResourceData, meta interface{}) err
 func resourceRancherRegistryDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Deleting Registry: %s", d.Id())
 	id := d.Id()
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
@@ -129,6 +163,12 @@ func resourceRancherRegistryDelete(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	if reg == nil || isRemovedState(reg.State) {
+		log.Printf("[WARN] Registry (%s) already removed", id)
+		d.SetId("")
+		return nil
+	}
+
 	// Step 1: Deactivate
 	if _, err := client.Registry.ActionDeactivate(reg); err != nil {
 		return fmt.Errorf("Error deactivating Registry: %s", err)
@@ -156,6 +196,10 @@ func resourceRancherRegistryDelete(d *schema.ResourceData, meta interface{}) err
 	if err != nil {
 		return fmt.Errorf("Failed to refresh state of deactivated registry (%s): %s", id, err)
 	}
+	if reg == nil {
+		d.SetId("")
+		return nil
+	}
 
 	// Step 2: Remove
 	if _, err := client.Registry.ActionRemove(reg); err != nil {
@@ -193,6 +237,10 @@ func RegistryStateRefreshFunc(client *rancher.RancherClient, registryID string)
 			return nil, "", err
 		}
 
+		if env == nil {
+			return &rancher.Registry{}, "removed", nil
+		}
+
 		return env, env.State, nil
 	}
 }
@@ -49,7 +49,6 @@ func resourceRancherRegistryCredential() *schema.Resource {
 			"secret_value": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 		},
 	}
@@ -61,6 +60,9 @@ func resourceRancherRegistryCredentialCreate(d *schema.ResourceData, meta interf
 	if err != nil {
 		return err
 	}
+	if client == nil {
+		return fmt.Errorf("Registry (%s) not found", d.Get("registry_id").(string))
+	}
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -94,12 +96,23 @@ func resourceRancherRegistryCredentialRead(d *schema.ResourceData, meta interfac
 	if err != nil {
 		return err
 	}
+	if client == nil {
+		log.Printf("[WARN] Registry (%s) of RegistryCredential (%s) not found, removing from state", d.Get("registry_id").(string), d.Id())
+		d.SetId("")
+		return nil
+	}
 
 	env, err := client.RegistryCredential.ById(d.Id())
 	if err != nil {
 		return err
 	}
 
+	if env == nil || isRemovedState(env.State) {
+		log.Printf("[WARN] RegistryCredential (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	log.Printf("[INFO] RegistryCredential Name: %s", env.Name)
 
 	d.Set("description", env.Description)
@@ -116,24 +129,58 @@ func resourceRancherRegistryCredentialUpdate(d *schema.ResourceData, meta interf
 	if err != nil {
 		return err
 	}
+	if client == nil {
+		return fmt.Errorf("Registry (%s) not found", d.Get("registry_id").(string))
+	}
 
 	registryCred, err := client.RegistryCredential.ById(d.Id())
 	if err != nil {
 		return err
 	}
+	if registryCred == nil {
+		return fmt.Errorf("RegistryCredential (%s) not found", d.Id())
+	}
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	email := d.Get("email").(string)
 	publicValue := d.Get("public_value").(string)
-	secretValue := d.Get("secret_value").(string)
 
-	registryCred.Name = name
-	registryCred.Description = description
-	registryCred.Email = email
-	registryCred.PublicValue = publicValue
-	registryCred.SecretValue = secretValue
-	client.RegistryCredential.Update(registryCred, &registryCred)
+	data := map[string]interface{}{
+		"name":        &name,
+		"description": &description,
+		"email":       &email,
+		"publicValue": &publicValue,
+	}
+
+	// The secret is rotated in place so images keep being pulled with the
+	// credential while it changes.
+	if d.HasChange("secret_value") {
+		secretValue := d.Get("secret_value").(string)
+		data["secretValue"] = &secretValue
+	}
+
+	var newRegistryCred rancher.RegistryCredential
+	if err := client.Update("registryCredential", &registryCred.Resource, data, &newRegistryCred); err != nil {
+		return fmt.Errorf("Error updating RegistryCredential: %s", err)
+	}
+
+	log.Printf("[DEBUG] Waiting for registry credential (%s) to be updated", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"active", "updating-active"},
+		Target:     []string{"active"},
+		Refresh:    RegistryCredentialStateRefreshFunc(client, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      1 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, waitErr := stateConf.WaitForState()
+	if waitErr != nil {
+		return fmt.Errorf(
+			"Error waiting for registry credential (%s) to be updated: %s", d.Id(), waitErr)
+	}
 
 	return resourceRancherRegistryCredentialRead(d, meta)
 }
@@ -145,12 +192,23 @@ func resourceRancherRegistryCredentialDelete(d *schema.ResourceData, meta interf
 	if err != nil {
 		return err
 	}
+	if client == nil {
+		log.Printf("[WARN] Registry (%s) of RegistryCredential (%s) not found, removing from state", d.Get("registry_id").(string), d.Id())
+		d.SetId("")
+		return nil
+	}
 
 	reg, err := client.RegistryCredential.ById(id)
 	if err != nil {
 		return err
 	}
 
+	if reg == nil || isRemovedState(reg.State) {
+		log.Printf("[WARN] RegistryCredential (%s) already removed", id)
+		d.SetId("")
+		return nil
+	}
+
 	// Step 1: Deactivate
 	if _, err := client.RegistryCredential.ActionDeactivate(reg); err != nil {
 		return fmt.Errorf("Error deactivating RegistryCredential: %s", err)
@@ -178,6 +236,10 @@ func resourceRancherRegistryCredentialDelete(d *schema.ResourceData, meta interf
 	if err != nil {
 		return fmt.Errorf("Failed to refresh state of deactivated registry credential (%s): %s", id, err)
 	}
+	if reg == nil {
+		d.SetId("")
+		return nil
+	}
 
 	// Step 2: Remove
 	if _, err := client.RegistryCredential.ActionRemove(reg); err != nil {
@@ -215,6 +277,10 @@ func RegistryCredentialStateRefreshFunc(client *rancher.RancherClient, registryC
 			return nil, "", err
 		}
 
+		if regC == nil {
+			return &rancher.RegistryCredential{}, "removed", nil
+		}
+
 		return regC, regC.State, nil
 	}
 }
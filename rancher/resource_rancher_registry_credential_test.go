@@ -27,6 +27,7 @@ func TestAccRancherRegistryCredential(t *testing.T) {
 			resource.TestStep{
 				Config: testAccRancherRegistryCredentialUpdateConfig,
 				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("rancher_registry_credential.foo", "id", &registry.Id),
 					testAccCheckRancherRegistryCredentialExists("rancher_registry_credential.foo", &registry),
 					testAccCheckRancherRegistryCredentialAttributes(&registry, "foo2", "registry credential test - updated", "user2"),
 				),
@@ -136,6 +137,6 @@ resource "rancher_registry_credential" "foo" {
 	registry_id = "${rancher_registry.foo.id}"
 	email = "registry@credential.com"
 	public_value = "user2"
-	secret_value = "pass"
+	secret_value = "pass2"
 }
  `
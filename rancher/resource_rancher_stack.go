@@ -36,7 +36,8 @@ func resourceRancherStack() *schema.Resource {
 			},
 			"environment_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"docker_compose": {
 				Type:     schema.TypeString,
@@ -71,7 +72,7 @@ func resourceRancherStack() *schema.Resource {
 
 func resourceRancherStackCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Creating Stack: %s", d.Id())
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
@@ -94,7 +95,7 @@ func resourceRancherStackCreate(d *schema.ResourceData, meta interface{}) error
 
 func resourceRancherStackRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Refreshing Stack: %s", d.Id())
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
@@ -104,6 +105,12 @@ func resourceRancherStackRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	if stack == nil || isRemovedState(stack.State) {
+		log.Printf("[WARN] Stack (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	log.Printf("[INFO] Stack Name: %s", stack.Name)
 
 	d.Set("description", stack.Description)
@@ -132,7 +139,7 @@ func resourceRancherStackRead(d *schema.ResourceData, meta interface{}) error {
 
 func resourceRancherStackUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Updating Stack: %s", d.Id())
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
@@ -149,6 +156,9 @@ func resourceRancherStackUpdate(d *schema.ResourceData, meta interface{}) error
 	if err != nil {
 		return err
 	}
+	if stack == nil {
+		return fmt.Errorf("Stack (%s) not found", d.Id())
+	}
 
 	if err := client.Update("environment", &stack.Resource, data, &newStack); err != nil {
 		return err
@@ -160,7 +170,7 @@ func resourceRancherStackUpdate(d *schema.ResourceData, meta interface{}) error
 func resourceRancherStackDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Deleting Stack: %s", d.Id())
 	id := d.Id()
-	client, err := meta.(*Config).EnvironmentClient(d.Get("environment_id").(string))
+	client, err := environmentClient(d, meta)
 	if err != nil {
 		return err
 	}
@@ -170,6 +180,12 @@ func resourceRancherStackDelete(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
+	if stack == nil || isRemovedState(stack.State) {
+		log.Printf("[WARN] Stack (%s) already removed", id)
+		d.SetId("")
+		return nil
+	}
+
 	if err := client.Environment.Delete(stack); err != nil {
 		return fmt.Errorf("Error deleting Stack: %s", err)
 	}
@@ -205,6 +221,10 @@ func StackStateRefreshFunc(client *rancher.RancherClient, stackID string) resour
 			return nil, "", err
 		}
 
+		if stack == nil {
+			return &rancher.Environment{}, "removed", nil
+		}
+
 		return stack, stack.State, nil
 	}
 }
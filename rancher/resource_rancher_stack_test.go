@@ -3,6 +3,7 @@ package rancher
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
@@ -49,6 +50,56 @@ func TestAccRancherStack(t *testing.T) {
 	})
 }
 
+func TestAccRancherStack_disappears(t *testing.T) {
+	var stack rancher.Environment
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRancherStackDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRancherStackConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRancherStackExists("rancher_stack.foo", &stack),
+					testAccRancherStackDisappears(&stack),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccRancherStackDisappears(stack *rancher.Environment) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := testAccProvider.Meta().(*Config).EnvironmentClient(stack.AccountId)
+		if err != nil {
+			return err
+		}
+
+		if err := client.Environment.Delete(stack); err != nil {
+			return fmt.Errorf("Error deleting Stack: %s", err)
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"active", "removed", "removing"},
+			Target:     []string{"removed"},
+			Refresh:    StackStateRefreshFunc(client, stack.Id),
+			Timeout:    10 * time.Minute,
+			Delay:      1 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		_, waitErr := stateConf.WaitForState()
+		if waitErr != nil {
+			return fmt.Errorf(
+				"Error waiting for stack (%s) to be removed: %s", stack.Id, waitErr)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckRancherStackExists(n string, stack *rancher.Environment) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -111,7 +162,7 @@ func testAccCheckRancherStackAttributes(stack *rancher.Environment, stackName st
 		}
 
 		if stack.StartOnCreate != startOnCreate {
-			return fmt.Errorf("Bad startOnCreate: %s should be: %s", stack.StartOnCreate, startOnCreate)
+			return fmt.Errorf("Bad startOnCreate: %t should be: %t", stack.StartOnCreate, startOnCreate)
 		}
 
 		return nil
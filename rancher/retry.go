@@ -0,0 +1,185 @@
+package rancher
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"time"
+)
+
+// retryTransport retries the requests to the Rancher API that fail with a
+// transient error: a 5xx or 409 response, or a connection error.
+//
+// Requests that are not idempotent, like creates and actions, are only
+// retried when the connection to the server could not be established, since
+// then it is certain that the server did not process them.
+//
+// Each attempt has its own timeout, set by an attemptTimeoutTransport, and
+// the timeout of the client covers all of them, see retryClientTimeout.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minBackoff: 500 * time.Millisecond,
+		maxBackoff: 30 * time.Second,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		// Once the request is canceled or its client timeout expires, the
+		// error comes from that and retrying can't succeed. The timeout of
+		// a single attempt is instead a retryable error.
+		if attempt >= t.maxRetries || req.Context().Err() != nil || !shouldRetryRequest(req, resp, err) {
+			return resp, err
+		}
+
+		if resp != nil {
+			ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			log.Printf("[WARN] %s %s returned %s, retrying (%d/%d)", req.Method, req.URL, resp.Status, attempt+1, t.maxRetries)
+		} else {
+			log.Printf("[WARN] %s %s failed: %s, retrying (%d/%d)", req.Method, req.URL, err, attempt+1, t.maxRetries)
+		}
+
+		timer := time.NewTimer(t.backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := time.Duration(math.Pow(2, float64(attempt))) * t.minBackoff
+	if wait > t.maxBackoff {
+		wait = t.maxBackoff
+	}
+	return wait
+}
+
+// retryClientTimeout returns the timeout of a client whose requests are
+// retried up to maxRetries times, with each attempt taking up to timeout.
+func retryClientTimeout(timeout time.Duration, maxRetries int) time.Duration {
+	if timeout == 0 {
+		return 0
+	}
+
+	t := newRetryTransport(nil, maxRetries)
+	total := time.Duration(maxRetries+1) * timeout
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		total += t.backoff(attempt)
+	}
+	return total
+}
+
+func shouldRetryRequest(req *http.Request, resp *http.Response, err error) bool {
+	// A certificate that failed verification won't pass it on a retry
+	if isTLSError(err) {
+		return false
+	}
+
+	if err != nil {
+		if isIdempotentMethod(req.Method) {
+			return true
+		}
+		opErr, ok := err.(*net.OpError)
+		return ok && opErr.Op == "dial"
+	}
+
+	if !isIdempotentMethod(req.Method) {
+		return false
+	}
+
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusConflict
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+func isTLSError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var verificationErr *tls.CertificateVerificationError
+	var recordHeaderErr tls.RecordHeaderError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certificateInvalidErr x509.CertificateInvalidError
+
+	return errors.As(err, &verificationErr) ||
+		errors.As(err, &recordHeaderErr) ||
+		errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &certificateInvalidErr)
+}
+
+// attemptTimeoutTransport limits the time each attempt of a request to the
+// Rancher API takes, including reading its response body.
+type attemptTimeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *attemptTimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody releases the context of a request once its body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
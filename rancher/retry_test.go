@@ -0,0 +1,161 @@
+package rancher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRetryServer(failures int, status int) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server, &requests
+}
+
+func testRetryClient(maxRetries int) *http.Client {
+	transport := newRetryTransport(http.DefaultTransport, maxRetries)
+	transport.minBackoff = time.Millisecond
+	return &http.Client{Transport: transport}
+}
+
+func TestRetryTransport(t *testing.T) {
+	server, requests := testRetryServer(2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	req, _ := http.NewRequest("PUT", server.URL, strings.NewReader(`{"name":"foo"}`))
+	resp, err := testRetryClient(3).Do(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Bad status: %d should be: %d", resp.StatusCode, http.StatusOK)
+	}
+	if *requests != 3 {
+		t.Fatalf("Bad requests: %d should be: %d", *requests, 3)
+	}
+}
+
+func TestRetryTransport_maxRetries(t *testing.T) {
+	server, requests := testRetryServer(5, http.StatusConflict)
+	defer server.Close()
+
+	resp, err := testRetryClient(2).Get(server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("Bad status: %d should be: %d", resp.StatusCode, http.StatusConflict)
+	}
+	if *requests != 3 {
+		t.Fatalf("Bad requests: %d should be: %d", *requests, 3)
+	}
+}
+
+func TestRetryTransport_createNotRetried(t *testing.T) {
+	server, requests := testRetryServer(1, http.StatusBadGateway)
+	defer server.Close()
+
+	resp, err := testRetryClient(3).Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("Bad status: %d should be: %d", resp.StatusCode, http.StatusBadGateway)
+	}
+	if *requests != 1 {
+		t.Fatalf("Bad requests: %d should be: %d", *requests, 1)
+	}
+}
+
+func TestRetryTransport_clientTimeout(t *testing.T) {
+	server, requests := testRetryServer(100, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	transport := newRetryTransport(http.DefaultTransport, 5)
+	transport.minBackoff = 100 * time.Millisecond
+	client := &http.Client{Transport: transport, Timeout: 250 * time.Millisecond}
+
+	start := time.Now()
+	_, err := client.Get(server.URL)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatalf("Expected a timeout error")
+	}
+	if elapsed > time.Second {
+		t.Fatalf("Retries continued after the client timeout: %s", elapsed)
+	}
+	if *requests > 3 {
+		t.Fatalf("Bad requests: %d should be at most: %d", *requests, 3)
+	}
+}
+
+func TestRetryTransport_attemptTimeout(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	attemptTimeout := &attemptTimeoutTransport{next: http.DefaultTransport, timeout: 100 * time.Millisecond}
+	transport := newRetryTransport(attemptTimeout, 3)
+	transport.minBackoff = time.Millisecond
+	client := &http.Client{Transport: transport, Timeout: retryClientTimeout(100*time.Millisecond, 3)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if requests != 2 {
+		t.Fatalf("Bad requests: %d should be: %d", requests, 2)
+	}
+}
+
+func TestRetryClientTimeout(t *testing.T) {
+	// 6 attempts of 10s, and waits of 0.5s, 1s, 2s, 4s and 8s between them
+	expected := 75500 * time.Millisecond
+	if timeout := retryClientTimeout(10*time.Second, 5); timeout != expected {
+		t.Fatalf("Bad timeout: %s should be: %s", timeout, expected)
+	}
+}
+
+func TestShouldRetryRequest_tlsError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// The test server certificate is self-signed, so it fails verification
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	_, err := (&http.Transport{}).RoundTrip(req)
+	if err == nil {
+		t.Fatal("Expected a certificate verification error")
+	}
+
+	if shouldRetryRequest(req, nil, err) {
+		t.Fatalf("Certificate verification errors should not be retried: %s", err)
+	}
+}
@@ -0,0 +1,64 @@
+package rancher
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// The go-rancher clients build their own http.Client on every request and
+// always use http.DefaultTransport. To be able to configure TLS and other
+// transport settings per provider, http.DefaultTransport is replaced with a
+// hostTransport that dispatches each request to the transport registered for
+// the Rancher server and access key it uses.
+//
+// Since requests carry nothing else identifying the provider that made them,
+// provider configurations with the same server and access key share the
+// transport of the one configured last. Requests to any other server, like
+// the ones made by other HTTP users in the plugin, go through the original
+// http.DefaultTransport.
+var (
+	transportsMu     sync.RWMutex
+	transports       = map[string]http.RoundTripper{}
+	defaultTransport http.RoundTripper
+)
+
+type hostTransport struct{}
+
+func (hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	accessKey, _, _ := req.BasicAuth()
+
+	transportsMu.RLock()
+	rt, ok := transports[transportKey(req.URL, accessKey)]
+	if !ok {
+		rt = defaultTransport
+	}
+	transportsMu.RUnlock()
+
+	return rt.RoundTrip(req)
+}
+
+// registerTransport makes every request to the server of apiURL authenticated
+// with accessKey go through rt.
+func registerTransport(apiURL string, accessKey string, rt http.RoundTripper) error {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return err
+	}
+
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	if _, ok := http.DefaultTransport.(hostTransport); !ok {
+		defaultTransport = http.DefaultTransport
+		http.DefaultTransport = hostTransport{}
+	}
+
+	transports[transportKey(u, accessKey)] = rt
+
+	return nil
+}
+
+func transportKey(u *url.URL, accessKey string) string {
+	return u.Scheme + "://" + accessKey + "@" + u.Host
+}
@@ -1,6 +1,11 @@
 package rancher
 
-import "github.com/rancher/go-rancher/client"
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/go-rancher/client"
+)
 
 // GetActiveOrchestration get the name of the active orchestration for a environment
 func GetActiveOrchestration(project *client.Project) string {
@@ -17,3 +22,46 @@ func GetActiveOrchestration(project *client.Project) string {
 
 	return orch
 }
+
+// isRemovedState returns whether a Rancher resource state means the resource
+// is being or has been removed
+func isRemovedState(state string) bool {
+	return state == "removing" || state == "removed" || state == "purging" || state == "purged"
+}
+
+// flattenPublicEndpoints converts the public endpoints of a Rancher resource
+// to a list of "ip:port" strings
+func flattenPublicEndpoints(endpoints []interface{}) []string {
+	result := make([]string, 0, len(endpoints))
+	for _, e := range endpoints {
+		endpoint, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, fmt.Sprintf("%v:%v", endpoint["ipAddress"], endpoint["port"]))
+	}
+	return result
+}
+
+// environmentClient returns a client for the environment of a resource. When
+// environment_id is not set, the default environment of the provider is used
+// and stored in environment_id.
+func environmentClient(d *schema.ResourceData, meta interface{}) (*client.RancherClient, error) {
+	config := meta.(*Config)
+
+	// Creating the generic client resolves the default environment
+	if _, err := config.Client(); err != nil {
+		return nil, err
+	}
+
+	envID := d.Get("environment_id").(string)
+	if envID == "" {
+		if config.EnvironmentID == "" {
+			return nil, fmt.Errorf("environment_id must be set when the provider has no default environment")
+		}
+		envID = config.EnvironmentID
+		d.Set("environment_id", envID)
+	}
+
+	return config.EnvironmentClient(envID)
+}